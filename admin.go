@@ -0,0 +1,156 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// ErrInvalidOverride is returned when a target is forced to a status other than ok or fail.
+var ErrInvalidOverride = errors.New("invalid override")
+
+// Drain makes Handler and ReadinessHandler respond with 503 without running any
+// checks, so that load balancers stop sending traffic before the application shuts
+// down. The status page explains that the instance is draining.
+func (c *HealthChecker) Drain() {
+	c.draining.Store(true)
+}
+
+// Undrain reverts Drain.
+func (c *HealthChecker) Undrain() {
+	c.draining.Store(false)
+}
+
+// Draining reports whether the HealthChecker is draining.
+func (c *HealthChecker) Draining() bool {
+	return c.draining.Load()
+}
+
+// ForceStatus reports the target with the given status, HealthTargetStatusOk or
+// HealthTargetStatusFail, regardless of the outcome of its check until ClearOverride
+// is called. The check keeps running and its error message is still reported.
+func (c *HealthChecker) ForceStatus(target string, status HealthTargetStatus) error {
+	if status != HealthTargetStatusOk && status != HealthTargetStatusFail {
+		return fmt.Errorf("%w: status must be %q or %q, got %q",
+			ErrInvalidOverride, HealthTargetStatusOk, HealthTargetStatusFail, status)
+	}
+
+	if _, err := c.targetIndex(target); err != nil {
+		return err
+	}
+
+	c.overridesMu.Lock()
+	defer c.overridesMu.Unlock()
+
+	if c.overrides == nil {
+		c.overrides = make(map[string]HealthTargetStatus)
+	}
+	c.overrides[target] = status
+
+	return nil
+}
+
+// ClearOverride removes the override of the target and reports whether it existed.
+func (c *HealthChecker) ClearOverride(target string) bool {
+	c.overridesMu.Lock()
+	defer c.overridesMu.Unlock()
+
+	_, ok := c.overrides[target]
+	delete(c.overrides, target)
+
+	return ok
+}
+
+// Overrides returns the forced statuses by target name.
+func (c *HealthChecker) Overrides() map[string]HealthTargetStatus {
+	c.overridesMu.RLock()
+	defer c.overridesMu.RUnlock()
+
+	return maps.Clone(c.overrides)
+}
+
+// applyOverrides reports the results of overridden targets with the forced status.
+// Overrides take precedence over maintenance windows.
+func (c *HealthChecker) applyOverrides(results []HealthCheckResult) []HealthCheckResult {
+	c.overridesMu.RLock()
+	defer c.overridesMu.RUnlock()
+
+	for i, result := range results {
+		if status, ok := c.overrides[result.Target.Name]; ok {
+			results[i].Status = status
+			results[i].Overridden = true
+		}
+	}
+
+	return results
+}
+
+// adminState is the response of the state endpoint of AdminHandler.
+type adminState struct {
+	Draining    bool                          `json:"draining"`
+	Overrides   map[string]HealthTargetStatus `json:"overrides"`
+	Maintenance []MaintenanceWindow           `json:"maintenance"`
+}
+
+// AdminHandler returns an HTTP handler for operators, relative to where it is
+// mounted (for example with http.StripPrefix):
+//
+//   - GET / returns the drain state, overrides and maintenance windows;
+//   - POST /drain and DELETE /drain call Drain and Undrain;
+//   - PUT /overrides?target=...&status=ok|fail calls ForceStatus;
+//   - DELETE /overrides?target=... calls ClearOverride;
+//   - /maintenance is served by MaintenanceHandler.
+//
+// Requests rejected by authorize get 401. A nil authorize rejects every request.
+func (c *HealthChecker) AdminHandler(authorize Authorizer) http.HandlerFunc {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		respondJSON(w, http.StatusOK, adminState{
+			Draining:    c.Draining(),
+			Overrides:   c.Overrides(),
+			Maintenance: c.Maintenance(),
+		})
+	})
+	mux.HandleFunc("POST /drain", func(w http.ResponseWriter, _ *http.Request) {
+		c.Drain()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /drain", func(w http.ResponseWriter, _ *http.Request) {
+		c.Undrain()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("PUT /overrides", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		err := c.ForceStatus(query.Get("target"), HealthTargetStatus(query.Get("status")))
+		switch {
+		case errors.Is(err, ErrUnknownTarget):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("DELETE /overrides", func(w http.ResponseWriter, r *http.Request) {
+		if !c.ClearOverride(r.URL.Query().Get("target")) {
+			http.Error(w, "override not found", http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.Handle("/maintenance", c.MaintenanceHandler())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorize == nil || !authorize(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,157 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_Drain(t *testing.T) {
+	t.Parallel()
+
+	checked := 0
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			checked++
+
+			return nil
+		}))
+
+	checker.Drain()
+	for name, handler := range map[string]http.HandlerFunc{
+		"handler":   checker.Handler(),
+		"readiness": checker.ReadinessHandler(),
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health?no_deps", nil))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("%s: expected status 503 while draining, got %d", name, rec.Code)
+		}
+	}
+	if checked != 0 {
+		t.Fatalf("expected no checks while draining, got %d", checked)
+	}
+
+	rec := httptest.NewRecorder()
+	NewPage(WithHealthChecker(checker)).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.Contains(rec.Body.String(), "This instance is draining") {
+		t.Fatal("expected the status page to explain draining")
+	}
+
+	checker.Undrain()
+	rec = executeHandlerRequest(t, checker, "")
+	assertStatusCode(t, http.StatusOK, rec.Code)
+}
+
+func TestHealthChecker_ForceStatus(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("refused")
+		})).
+		WithTarget("redis", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+	if err := checker.ForceStatus("postgres", HealthTargetStatusOk); err != nil {
+		t.Fatalf("force postgres: %v", err)
+	}
+	if err := checker.ForceStatus("redis", HealthTargetStatusFail); err != nil {
+		t.Fatalf("force redis: %v", err)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusOk || !results[0].Overridden || results[0].ErrorMessage != "refused" {
+		t.Fatalf("unexpected forced ok result: %+v", results[0])
+	}
+	if results[1].Status != HealthTargetStatusFail || !results[1].Overridden {
+		t.Fatalf("unexpected forced fail result: %+v", results[1])
+	}
+
+	if !checker.ClearOverride("redis") || checker.ClearOverride("redis") {
+		t.Fatal("expected the override to be cleared once")
+	}
+	if overrides := checker.Overrides(); len(overrides) != 1 || overrides["postgres"] != HealthTargetStatusOk {
+		t.Fatalf("unexpected overrides: %v", overrides)
+	}
+
+	if err := checker.ForceStatus("kafka", HealthTargetStatusOk); !errors.Is(err, ErrUnknownTarget) {
+		t.Fatalf("expected ErrUnknownTarget, got %v", err)
+	}
+	if err := checker.ForceStatus("postgres", HealthTargetStatusDegraded); !errors.Is(err, ErrInvalidOverride) {
+		t.Fatalf("expected ErrInvalidOverride, got %v", err)
+	}
+}
+
+func TestHealthChecker_AdminHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+	handler := http.StripPrefix("/admin", checker.AdminHandler(BearerToken("secret")))
+
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	tests := []struct {
+		method, path, token string
+		expected            int
+	}{
+		{http.MethodPost, "/admin/drain", "", http.StatusUnauthorized},
+		{http.MethodPost, "/admin/drain", "wrong", http.StatusUnauthorized},
+		{http.MethodPost, "/admin/drain", "secret", http.StatusNoContent},
+		{http.MethodPut, "/admin/overrides?target=postgres&status=fail", "secret", http.StatusNoContent},
+		{http.MethodPut, "/admin/overrides?target=kafka&status=fail", "secret", http.StatusNotFound},
+		{http.MethodPut, "/admin/overrides?target=postgres&status=degraded", "secret", http.StatusBadRequest},
+		{http.MethodGet, "/admin/maintenance", "secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rec := serve(tt.method, tt.path, tt.token); rec.Code != tt.expected {
+			t.Fatalf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.expected, rec.Code)
+		}
+	}
+
+	rec := serve(http.MethodGet, "/admin/", "secret")
+	assertStatusCode(t, http.StatusOK, rec.Code)
+
+	var state adminState
+	if err := json.NewDecoder(rec.Body).Decode(&state); err != nil {
+		t.Fatalf("decode state: %v", err)
+	}
+	if !state.Draining || state.Overrides["postgres"] != HealthTargetStatusFail {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	for _, path := range []string{"/admin/drain", "/admin/overrides?target=postgres"} {
+		if rec := serve(http.MethodDelete, path, "secret"); rec.Code != http.StatusNoContent {
+			t.Fatalf("DELETE %s: expected status 204, got %d", path, rec.Code)
+		}
+	}
+	if checker.Draining() || len(checker.Overrides()) != 0 {
+		t.Fatal("expected drain and overrides to be reverted")
+	}
+
+	rec = httptest.NewRecorder()
+	checker.AdminHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assertStatusCode(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,68 @@
+package status
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Authorizer reports whether a request may access a protected handler or the
+// full details of check results.
+type Authorizer func(r *http.Request) bool
+
+// BearerToken returns an Authorizer accepting requests with the header
+// "Authorization: Bearer <token>". An empty token rejects every request.
+func BearerToken(token string) Authorizer {
+	return func(r *http.Request) bool {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		return ok && token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+	}
+}
+
+// BasicAuth returns an Authorizer accepting requests with the given HTTP basic
+// authentication credentials. An empty password rejects every request.
+func BasicAuth(username, password string) Authorizer {
+	return func(r *http.Request) bool {
+		givenUser, givenPassword, ok := r.BasicAuth()
+		userMatch := subtle.ConstantTimeCompare([]byte(givenUser), []byte(username)) == 1
+		passwordMatch := subtle.ConstantTimeCompare([]byte(givenPassword), []byte(password)) == 1
+
+		return ok && password != "" && userMatch && passwordMatch
+	}
+}
+
+// WithAuthorizer restricts the details of check results served by Handler, the
+// probe handlers, HealthJSONHandler and, unless WithPageAuthorizer is set, the
+// status page to requests accepted by authorize. Other requests get a redacted
+// view with the status of each target and the conclusion only, with the same
+// status code. Error messages, details, observed values and maintenance reasons
+// may contain hostnames or DSN fragments and are never shown to them.
+func WithAuthorizer(authorize Authorizer) CheckerOption {
+	return func(c *HealthChecker) {
+		c.authorize = authorize
+	}
+}
+
+// visibleResults returns the results as the request may see them.
+func (c *HealthChecker) visibleResults(r *http.Request, results []HealthCheckResult) []HealthCheckResult {
+	if c.authorize == nil || c.authorize(r) {
+		return results
+	}
+
+	return redact(results)
+}
+
+// redact returns copies of the results with the target and status only.
+func redact(results []HealthCheckResult) []HealthCheckResult {
+	redacted := make([]HealthCheckResult, len(results))
+	for i, result := range results {
+		redacted[i] = HealthCheckResult{
+			Target:    result.Target,
+			Status:    result.Status,
+			CheckedAt: result.CheckedAt,
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,136 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestAuthorizers(t *testing.T) {
+	t.Parallel()
+
+	bearer := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+
+		return r
+	}
+	basic := func(username, password string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.SetBasicAuth(username, password)
+
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		authorize Authorizer
+		request   *http.Request
+		expected  bool
+	}{
+		{"bearer valid", BearerToken("secret"), bearer("secret"), true},
+		{"bearer wrong", BearerToken("secret"), bearer("guess"), false},
+		{"bearer missing", BearerToken("secret"), httptest.NewRequest(http.MethodGet, "/", nil), false},
+		{"bearer empty token", BearerToken(""), bearer(""), false},
+		{"basic valid", BasicAuth("ops", "secret"), basic("ops", "secret"), true},
+		{"basic wrong user", BasicAuth("ops", "secret"), basic("dev", "secret"), false},
+		{"basic wrong password", BasicAuth("ops", "secret"), basic("ops", "guess"), false},
+		{"basic empty password", BasicAuth("ops", ""), basic("ops", ""), false},
+	}
+
+	for _, tt := range tests {
+		if actual := tt.authorize(tt.request); actual != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestHealthChecker_WithAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithAuthorizer(BearerToken("secret"))).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("dial tcp 10.0.0.12:5432: connection refused")
+		}))
+
+	handlers := map[string]http.HandlerFunc{
+		"handler":    checker.Handler(),
+		"readiness":  checker.ReadinessHandler(),
+		"healthjson": checker.HealthJSONHandler(ServiceInfo{}),
+	}
+
+	for name, handler := range handlers {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assertStatusCode(t, http.StatusInternalServerError, rec.Code)
+		if body := rec.Body.String(); strings.Contains(body, "10.0.0.12") || !strings.Contains(body, "fail") {
+			t.Fatalf("%s: expected a redacted failure, got %s", name, body)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if body := rec.Body.String(); !strings.Contains(body, "10.0.0.12") {
+			t.Fatalf("%s: expected full details when authorized, got %s", name, body)
+		}
+	}
+}
+
+func TestPage_Handler_Redacted(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithAuthorizer(BearerToken("token"))).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("dial tcp 10.0.0.12:5432: connection refused")
+		}))
+	page := NewPage(WithHealthChecker(checker), WithPageAuthorizer(BasicAuth("ops", "secret"), `Basic realm="status"`))
+	handler := page.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	body := rec.Body.String()
+	if strings.Contains(body, "10.0.0.12") || !strings.Contains(body, "Not Good") || !strings.Contains(body, "postgres") {
+		t.Fatalf("expected a redacted page with the conclusion, got %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status?details", nil))
+	assertStatusCode(t, http.StatusUnauthorized, rec.Code)
+	if challenge := rec.Header().Get("WWW-Authenticate"); !strings.HasPrefix(challenge, "Basic ") {
+		t.Fatalf("expected a basic authentication challenge, got %q", challenge)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/status?details", nil)
+	req.SetBasicAuth("ops", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if !strings.Contains(rec.Body.String(), "10.0.0.12") {
+		t.Fatal("expected full details with the page credentials")
+	}
+}
+
+func TestPage_Handler_NoChallengeForCheckerAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithAuthorizer(BearerToken("token"))).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("dial tcp 10.0.0.12:5432: connection refused")
+		}))
+	handler := NewPage(WithHealthChecker(checker)).Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status?details", nil))
+	assertStatusCode(t, http.StatusOK, rec.Code)
+	if challenge := rec.Header().Get("WWW-Authenticate"); challenge != "" {
+		t.Fatalf("expected no challenge for a bearer token, got %q", challenge)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "Sign in") || strings.Contains(body, "10.0.0.12") {
+		t.Fatalf("expected a redacted page without a sign in link, got %s", body)
+	}
+}
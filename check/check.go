@@ -2,6 +2,7 @@ package check
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,6 +15,37 @@ const (
 	defaultRetryDelay = time.Second
 )
 
+// ErrDegraded marks a check error as degradation: the target works, but not as well as it should.
+var ErrDegraded = errors.New("degraded")
+
+// degradedError wraps an error and marks it as degraded.
+type degradedError struct {
+	err error
+}
+
+func (e *degradedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *degradedError) Unwrap() []error {
+	return []error{ErrDegraded, e.err}
+}
+
+// Degraded wraps err to signal that the target is degraded rather than failed,
+// for example because it responds slower than expected.
+func Degraded(err error) error {
+	if err == nil {
+		return ErrDegraded
+	}
+
+	return &degradedError{err: err}
+}
+
+// IsDegraded reports whether err signals degradation. See Degraded.
+func IsDegraded(err error) bool {
+	return errors.Is(err, ErrDegraded)
+}
+
 // Config holds common configuration for health checks.
 type Config struct {
 	Timeout    time.Duration
@@ -65,35 +97,85 @@ func (f CheckFunc) Check(ctx context.Context) error {
 	return f(ctx)
 }
 
-// WithTimeout wraps a Check with a timeout.
+// Result carries structured metadata about a check run, such as the measured
+// latency or the version of a server. It is reported alongside the error.
+type Result struct {
+	// Details contains arbitrary key/value metadata.
+	Details map[string]any
+	// ObservedValue is the main value measured by the check, for example the free disk space.
+	ObservedValue any
+	// Unit is the unit of ObservedValue, for example "ms" or "GB".
+	Unit string
+	// Measurement names ObservedValue, for example "freeSpace". It is used as the
+	// measurement name in the application/health+json format.
+	Measurement string
+}
+
+// ResultCheck is implemented by checks that report a Result in addition to an error.
+// Use Run to execute any Check and obtain its Result when available.
+type ResultCheck interface {
+	Check
+	// CheckResult performs the health check and returns its Result and an error if unhealthy.
+	CheckResult(ctx context.Context) (Result, error)
+}
+
+// ResultCheckFunc is a function type that implements the ResultCheck interface.
+type ResultCheckFunc func(ctx context.Context) (Result, error)
+
+// Check implements the Check interface for ResultCheckFunc.
+func (f ResultCheckFunc) Check(ctx context.Context) error {
+	_, err := f(ctx)
+
+	return err
+}
+
+// CheckResult implements the ResultCheck interface for ResultCheckFunc.
+func (f ResultCheckFunc) CheckResult(ctx context.Context) (Result, error) {
+	return f(ctx)
+}
+
+// Run performs the check. The Result is empty unless the check implements ResultCheck.
+func Run(ctx context.Context, check Check) (Result, error) {
+	if rc, ok := check.(ResultCheck); ok {
+		return rc.CheckResult(ctx)
+	}
+
+	return Result{}, check.Check(ctx)
+}
+
+// WithTimeout wraps a Check with a timeout. The Result of the check is preserved.
 func WithTimeout(check Check, timeout time.Duration) Check {
-	return CheckFunc(func(ctx context.Context) error {
+	return ResultCheckFunc(func(ctx context.Context) (Result, error) {
 		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
-		return check.Check(ctx)
+		return Run(ctx, check)
 	})
 }
 
-// WithRetries wraps a Check with retry logic.
+// WithRetries wraps a Check with retry logic. Degraded results are not retried.
+// The Result of the last attempt is preserved.
 func WithRetries(check Check, attempts int, delay time.Duration) Check {
-	return CheckFunc(func(ctx context.Context) error {
-		var lastErr error
+	return ResultCheckFunc(func(ctx context.Context) (Result, error) {
+		var (
+			lastResult Result
+			lastErr    error
+		)
 		for i := 0; i < attempts; i++ {
-			err := check.Check(ctx)
-			if err == nil {
-				return nil
+			result, err := Run(ctx, check)
+			if err == nil || IsDegraded(err) {
+				return result, err
 			}
-			lastErr = err
+			lastResult, lastErr = result, err
 
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return lastResult, ctx.Err()
 			case <-time.After(delay):
 			}
 		}
 
-		return lastErr
+		return lastResult, lastErr
 	})
 }
 
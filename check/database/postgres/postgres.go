@@ -9,18 +9,29 @@ import (
 	_ "github.com/lib/pq" // register postgres driver
 )
 
-// Check creates a health check for PostgreSQL.
+// Check creates a health check for PostgreSQL. The server version is reported
+// in the details of the result when the server allows querying it.
 func Check(dsn string, config check.Config) check.Check {
-	return check.CheckFunc(func(ctx context.Context) error {
+	return check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
 		db, err := sql.Open("postgres", dsn)
 		if err != nil {
-			return fmt.Errorf("failed to connect to postgres: %w", err)
+			return check.Result{}, fmt.Errorf("failed to connect to postgres: %w", err)
 		}
 		defer db.Close()
 
 		ctx, cancel := context.WithTimeout(ctx, config.Timeout)
 		defer cancel()
 
-		return db.PingContext(ctx)
+		if err := db.PingContext(ctx); err != nil {
+			return check.Result{}, err
+		}
+
+		var result check.Result
+		var version string
+		if db.QueryRowContext(ctx, "SHOW server_version").Scan(&version) == nil {
+			result.Details = map[string]any{"server_version": version}
+		}
+
+		return result, nil
 	})
 }
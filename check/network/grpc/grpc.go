@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/alarmistdev/status/check"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Option configures a gRPC health check.
+type Option func(*options)
+
+type options struct {
+	creds       credentials.TransportCredentials
+	dialOptions []grpc.DialOption
+}
+
+// WithTLS connects with TLS using the given configuration. A nil config uses
+// the system roots and the host of the address as the server name.
+// By default the connection is not encrypted.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.creds = credentials.NewTLS(config)
+	}
+}
+
+// WithDialOptions adds options to the gRPC client, for example per-RPC credentials.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// Check creates a health check calling the grpc.health.v1.Health/Check method of
+// the server at addr ("host:port") for the given service. The empty service name
+// checks the server as a whole. The check fails unless the service is SERVING.
+func Check(addr, service string, config check.Config, opts ...Option) check.Check {
+	o := options{creds: insecure.NewCredentials()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return check.CheckFunc(func(ctx context.Context) error {
+		if config.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+			defer cancel()
+		}
+
+		dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(o.creds)}, o.dialOptions...)
+		conn, err := grpc.NewClient(addr, dialOptions...)
+		if err != nil {
+			return fmt.Errorf("failed to create client for %s: %w", addr, err)
+		}
+		defer conn.Close()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return fmt.Errorf("failed to check health of %s: %w", addr, err)
+		}
+
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("service %q is %s", service, resp.GetStatus())
+		}
+
+		return nil
+	})
+}
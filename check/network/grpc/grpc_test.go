@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/check"
+	"github.com/alarmistdev/status/grpchealth"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	checker := status.NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}), status.WithImportance(status.TargetImportanceLow))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, grpchealth.NewServer(checker,
+		grpchealth.WithTargetService("orders.Postgres", "postgres"),
+	))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	addr := listener.Addr().String()
+	config := check.DefaultConfig()
+
+	if err := Check(addr, "", config).Check(context.Background()); err != nil {
+		t.Fatalf("expected the server to be serving, got %v", err)
+	}
+
+	err = Check(addr, "orders.Postgres", config).Check(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "NOT_SERVING") {
+		t.Fatalf("expected NOT_SERVING, got %v", err)
+	}
+
+	if err := Check(addr, "unknown", config).Check(context.Background()); err == nil {
+		t.Fatal("expected an error for an unknown service")
+	}
+
+	tlsCheck := Check(addr, "", config, WithTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	if err := tlsCheck.Check(context.Background()); err == nil {
+		t.Fatal("expected a TLS handshake error with a plaintext server")
+	}
+}
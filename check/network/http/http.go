@@ -8,15 +8,19 @@ import (
 	"net/http"
 
 	"github.com/alarmistdev/status/check"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // Check creates a health check for HTTP endpoints with custom path and expected status.
+// The trace context of ctx is propagated to the endpoint using the global OpenTelemetry propagator.
 func Check(method, url string, expectedStatus int, config check.Config) check.Check {
 	return check.CheckFunc(func(ctx context.Context) error {
 		req, err := http.NewRequestWithContext(ctx, method, url, nil)
 		if err != nil {
 			return fmt.Errorf("failed to create request: %w", err)
 		}
+		injectTraceContext(req)
 
 		client := &http.Client{Timeout: config.Timeout}
 		resp, err := client.Do(req)
@@ -34,6 +38,7 @@ func Check(method, url string, expectedStatus int, config check.Config) check.Ch
 }
 
 // CheckGraphQL creates a health check for GraphQL endpoints.
+// The trace context of ctx is propagated to the endpoint using the global OpenTelemetry propagator.
 func CheckGraphQL(method, url string, expectedStatus int, config check.Config) check.Check {
 	return check.CheckFunc(func(ctx context.Context) error {
 		query := `{ __schema { types { name } } }`
@@ -49,6 +54,7 @@ func CheckGraphQL(method, url string, expectedStatus int, config check.Config) c
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 		req.Header.Set("Content-Type", "application/json")
+		injectTraceContext(req)
 
 		client := &http.Client{Timeout: config.Timeout}
 		resp, err := client.Do(req)
@@ -64,3 +70,8 @@ func CheckGraphQL(method, url string, expectedStatus int, config check.Config) c
 		return nil
 	})
 }
+
+// injectTraceContext writes the trace context of the request context into its headers.
+func injectTraceContext(req *http.Request) {
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+}
@@ -10,30 +10,41 @@ import (
 	"github.com/alarmistdev/status/check"
 )
 
-// Check creates a health check for network latency.
+const defaultDialTimeout = 5 * time.Second
+
+// Check creates a health check for network latency. The check is degraded
+// when the connection takes longer than maxLatency and fails when the
+// connection cannot be established at all. The measured latency is reported
+// in milliseconds as the observed value.
 func Check(host string, port int, maxLatency time.Duration) check.Check {
-	return check.CheckFunc(func(ctx context.Context) error {
+	return check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return check.Result{}, ctx.Err()
 		default:
 		}
 
 		addr := net.JoinHostPort(host, strconv.Itoa(port))
 		start := time.Now()
 
-		dialer := &net.Dialer{Timeout: maxLatency}
+		dialer := &net.Dialer{Timeout: max(maxLatency, defaultDialTimeout)}
 		conn, err := dialer.DialContext(ctx, "tcp", addr)
 		if err != nil {
-			return fmt.Errorf("failed to connect to %s: %w", addr, err)
+			return check.Result{}, fmt.Errorf("failed to connect to %s: %w", addr, err)
 		}
 		defer conn.Close()
 
 		latency := time.Since(start)
+		result := check.Result{
+			ObservedValue: float64(latency) / float64(time.Millisecond),
+			Unit:          "ms",
+			Measurement:   "latency",
+			Details:       map[string]any{"address": addr},
+		}
 		if latency > maxLatency {
-			return fmt.Errorf("high latency: %v (maximum: %v)", latency, maxLatency)
+			return result, check.Degraded(fmt.Errorf("high latency: %v (maximum: %v)", latency, maxLatency))
 		}
 
-		return nil
+		return result, nil
 	})
 }
@@ -0,0 +1,178 @@
+// Package remote provides a federated health check that aggregates another
+// service exposing the Handler of a status.HealthChecker, so that an umbrella
+// status page can report many services without duplicating their checks.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/alarmistdev/status/check"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// maxBodySize limits the size of the remote response.
+const maxBodySize = 1 << 20
+
+// Remote statuses and importances, as reported by status.HealthChecker.Handler.
+const (
+	statusFail     = "fail"
+	statusDegraded = "degraded"
+	importanceLow  = "low"
+)
+
+// Option configures a remote check.
+type Option func(*options)
+
+type options struct {
+	client  *http.Client
+	headers http.Header
+	nested  bool
+}
+
+// WithHTTPClient sets the HTTP client used to fetch the remote results.
+// By default a client with the timeout of the check.Config is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithHeader adds a header to the request, for example the Authorization
+// header required to see the error messages of a remote using status.WithAuthorizer.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.headers.Add(key, value)
+	}
+}
+
+// WithNestedResults reports the status of every remote target, and its error
+// message when failing, in the details of the result, so that they are shown on
+// the status page.
+func WithNestedResults() Option {
+	return func(o *options) {
+		o.nested = true
+	}
+}
+
+// result is a result of the remote status.HealthChecker.Handler.
+type result struct {
+	Target struct {
+		Name       string `json:"name"`
+		Importance string `json:"importance"`
+	} `json:"target"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error"`
+}
+
+// Check creates a health check fetching the results of status.HealthChecker.Handler
+// at url. The remote conclusion is mapped to the outcome of the check: the check
+// fails when a high importance remote target fails, is degraded when a low importance
+// target fails or a target is degraded, and passes otherwise. The check also fails
+// when the remote cannot be reached or responds with a status code other than
+// 200 or 500, for example 503 while draining.
+// The trace context of ctx is propagated to the remote using the global OpenTelemetry propagator.
+func Check(url string, config check.Config, opts ...Option) check.Check {
+	o := options{headers: make(http.Header)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.client == nil {
+		o.client = &http.Client{Timeout: config.Timeout}
+	}
+
+	return check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
+		results, err := fetch(ctx, o, url)
+		if err != nil {
+			return check.Result{}, err
+		}
+
+		var res check.Result
+		if o.nested {
+			res.Details = nestedDetails(results)
+		}
+
+		return res, conclude(results)
+	})
+}
+
+// fetch requests and decodes the remote results.
+func fetch(ctx context.Context, o options, url string) ([]result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range o.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusInternalServerError {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var results []result
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode remote results: %w", err)
+	}
+
+	return results, nil
+}
+
+// conclude maps the remote results to the error of the check.
+func conclude(results []result) error {
+	var failed, degraded []string
+	for _, r := range results {
+		switch {
+		case r.Status == statusFail && r.Target.Importance != importanceLow:
+			failed = append(failed, describe(r))
+		case r.Status == statusFail || r.Status == statusDegraded:
+			degraded = append(degraded, describe(r))
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		return fmt.Errorf("remote targets failing: %s", strings.Join(failed, "; "))
+	case len(degraded) > 0:
+		return check.Degraded(fmt.Errorf("remote targets degraded: %s", strings.Join(degraded, "; ")))
+	default:
+		return nil
+	}
+}
+
+// nestedDetails returns the status of every remote target by name, followed
+// by the error message when there is one.
+func nestedDetails(results []result) map[string]any {
+	details := make(map[string]any, len(results))
+	for _, r := range results {
+		if r.ErrorMessage != "" {
+			details[r.Target.Name] = r.Status + ": " + r.ErrorMessage
+		} else {
+			details[r.Target.Name] = r.Status
+		}
+	}
+
+	return details
+}
+
+// describe formats a failing or degraded remote result as "name: error".
+func describe(r result) string {
+	if r.ErrorMessage == "" {
+		return r.Target.Name + ": " + r.Status
+	}
+
+	return r.Target.Name + ": " + r.ErrorMessage
+}
@@ -0,0 +1,109 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/check"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	ok := check.CheckFunc(func(ctx context.Context) error {
+		return nil
+	})
+	refused := check.CheckFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	tests := []struct {
+		name     string
+		checker  *status.HealthChecker
+		wantErr  bool
+		degraded bool
+	}{
+		{
+			name:    "pass",
+			checker: status.NewHealthChecker().WithTarget("postgres", ok),
+		},
+		{
+			name: "degraded",
+			checker: status.NewHealthChecker().
+				WithTarget("postgres", ok).
+				WithTarget("cache", refused, status.WithImportance(status.TargetImportanceLow)),
+			wantErr:  true,
+			degraded: true,
+		},
+		{
+			name:    "fail",
+			checker: status.NewHealthChecker().WithTarget("postgres", refused),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.checker.Handler())
+			t.Cleanup(server.Close)
+
+			_, err := check.Run(context.Background(), Check(server.URL, check.DefaultConfig()))
+			if (err != nil) != tt.wantErr || check.IsDegraded(err) != tt.degraded {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheck_NestedResults(t *testing.T) {
+	t.Parallel()
+
+	checker := status.NewHealthChecker(status.WithAuthorizer(status.BearerToken("secret"))).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})).
+		WithTarget("redis", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+	server := httptest.NewServer(checker.Handler())
+	defer server.Close()
+
+	result, err := check.Run(context.Background(), Check(server.URL, check.DefaultConfig(),
+		WithNestedResults(), WithHeader("Authorization", "Bearer secret")))
+	if err == nil || !strings.Contains(err.Error(), "postgres: connection refused") {
+		t.Fatalf("expected the remote failure, got %v", err)
+	}
+	if result.Details["postgres"] != "fail: connection refused" || result.Details["redis"] != "ok" {
+		t.Fatalf("unexpected nested results: %v", result.Details)
+	}
+}
+
+func TestCheck_UnexpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	checker := status.NewHealthChecker()
+	checker.Drain()
+	server := httptest.NewServer(checker.Handler())
+	defer server.Close()
+
+	_, err := check.Run(context.Background(), Check(server.URL, check.DefaultConfig()))
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected an error for a draining remote, got %v", err)
+	}
+
+	notJSON := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer notJSON.Close()
+
+	if _, err := check.Run(context.Background(), Check(notJSON.URL, check.DefaultConfig())); err == nil {
+		t.Fatal("expected an error for a response that is not JSON")
+	}
+}
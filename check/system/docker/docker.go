@@ -19,12 +19,18 @@ type dockerClient interface {
 	ContainerList(ctx context.Context, options containerTypes.ListOptions) ([]dockertypes.Container, error)
 }
 
-// dockerCheck periodically verifies that containers matching the provided labels are running.
+// containersCheck verifies on every run that containers matching the provided
+// labels are running.
+type containersCheck struct {
+	client  dockerClient
+	labels  map[string]string
+	timeout time.Duration
+}
+
+// dockerCheck periodically refreshes a containersCheck and serves its latest result.
 type dockerCheck struct {
-	client   dockerClient
-	labels   map[string]string
-	interval time.Duration
-	timeout  time.Duration
+	containers *containersCheck
+	interval   time.Duration
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -32,35 +38,39 @@ type dockerCheck struct {
 
 	mu          sync.RWMutex
 	lastErr     error
+	lastCount   int
 	lastChecked time.Time
 }
 
+// OnDemandCheck creates a health check that ensures all containers with the given labels
+// are running, listing them through the local Docker socket on every run. It suits a
+// HealthChecker running in background mode, which runs it on the target interval (see
+// status.WithInterval). It fails if no containers match or if any matched container is
+// not running. Close releases the Docker client.
+func OnDemandCheck(labels map[string]string, config check.Config) (check.Check, error) {
+	return newContainersCheck(labels, config, nil)
+}
+
 // Check creates a health check that periodically ensures all containers with the given labels are running.
 // The check uses the local Docker socket and verifies that every matching container is in the "running" state.
 // It fails if no containers match, if any matched container is not running, or if the background result is stale.
+//
+// The check refreshes on its own interval, so that handlers of a HealthChecker executing checks
+// inline never call the Docker API. Use OnDemandCheck with a HealthChecker in background mode.
 func Check(labels map[string]string, interval time.Duration, config check.Config) (check.Check, error) {
 	return newCheck(labels, interval, config, nil)
 }
 
-func newCheck(
-	labels map[string]string,
-	interval time.Duration,
-	config check.Config,
-	cli dockerClient,
-) (*dockerCheck, error) {
+func newContainersCheck(labels map[string]string, config check.Config, cli dockerClient) (*containersCheck, error) {
 	if len(labels) == 0 {
 		return nil, errors.New("labels must not be empty")
 	}
-	if interval <= 0 {
-		return nil, errors.New("interval must be positive")
-	}
 	if config.Timeout == 0 {
 		config.Timeout = check.DefaultConfig().Timeout
 	}
 
-	var err error
-
 	if cli == nil {
+		var err error
 		cli, err = client.NewClientWithOpts(
 			client.WithHost(client.DefaultDockerHost),
 			client.WithAPIVersionNegotiation(),
@@ -70,14 +80,34 @@ func newCheck(
 		}
 	}
 
+	return &containersCheck{
+		client:  cli,
+		labels:  labels,
+		timeout: config.Timeout,
+	}, nil
+}
+
+func newCheck(
+	labels map[string]string,
+	interval time.Duration,
+	config check.Config,
+	cli dockerClient,
+) (*dockerCheck, error) {
+	if interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+
+	containers, err := newContainersCheck(labels, config, cli)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	dc := &dockerCheck{
-		client:   cli,
-		labels:   labels,
-		interval: interval,
-		timeout:  config.Timeout,
-		ctx:      ctx,
-		cancel:   cancel,
+		containers: containers,
+		interval:   interval,
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 
 	dc.refresh(ctx)
@@ -105,52 +135,54 @@ func (dc *dockerCheck) loop() {
 }
 
 func (dc *dockerCheck) refresh(parent context.Context) {
-	ctx, cancel := context.WithTimeout(parent, dc.timeout)
+	ctx, cancel := context.WithTimeout(parent, dc.containers.timeout)
 	defer cancel()
 
-	err := dc.checkContainers(ctx)
+	count, err := dc.containers.checkContainers(ctx)
 
 	dc.mu.Lock()
 	dc.lastErr = err
+	dc.lastCount = count
 	dc.lastChecked = time.Now()
 	dc.mu.Unlock()
 }
 
-func (dc *dockerCheck) checkContainers(ctx context.Context) error {
+// checkContainers returns the number of containers matching the labels.
+func (cc *containersCheck) checkContainers(ctx context.Context) (int, error) {
 	args := filters.NewArgs()
-	for k, v := range dc.labels {
+	for k, v := range cc.labels {
 		args.Add("label", fmt.Sprintf("%s=%s", k, v))
 	}
 
-	containers, err := dc.client.ContainerList(ctx, containerTypes.ListOptions{
+	containers, err := cc.client.ContainerList(ctx, containerTypes.ListOptions{
 		All:     true,
 		Filters: args,
 	})
 	if err != nil {
-		return fmt.Errorf("list docker containers: %w", err)
+		return 0, fmt.Errorf("list docker containers: %w", err)
 	}
 
 	matched := 0
 	for _, container := range containers {
-		if !dc.matchesLabels(container.Labels) {
+		if !cc.matchesLabels(container.Labels) {
 			continue
 		}
 		matched++
 
 		if container.State != "running" {
-			return fmt.Errorf("container %s not running (state=%s)", container.ID, container.State)
+			return matched, fmt.Errorf("container %s not running (state=%s)", container.ID, container.State)
 		}
 	}
 
 	if matched == 0 {
-		return fmt.Errorf("no containers found with labels %v", dc.labels)
+		return 0, fmt.Errorf("no containers found with labels %v", cc.labels)
 	}
 
-	return nil
+	return matched, nil
 }
 
-func (dc *dockerCheck) matchesLabels(found map[string]string) bool {
-	for key, expected := range dc.labels {
+func (cc *containersCheck) matchesLabels(found map[string]string) bool {
+	for key, expected := range cc.labels {
 		if found[key] != expected {
 			return false
 		}
@@ -159,28 +191,77 @@ func (dc *dockerCheck) matchesLabels(found map[string]string) bool {
 	return true
 }
 
+// result reports the number of containers matching the labels as the observed value.
+func (cc *containersCheck) result(count int) check.Result {
+	return check.Result{
+		ObservedValue: count,
+		Unit:          "containers",
+		Measurement:   "runningContainers",
+		Details:       map[string]any{"labels": cc.labels},
+	}
+}
+
+// Check implements the check.Check interface by listing the containers.
+func (cc *containersCheck) Check(ctx context.Context) error {
+	_, err := cc.CheckResult(ctx)
+
+	return err
+}
+
+// CheckResult implements the check.ResultCheck interface. The number of containers
+// matching the labels is reported as the observed value.
+func (cc *containersCheck) CheckResult(ctx context.Context) (check.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, cc.timeout)
+	defer cancel()
+
+	count, err := cc.checkContainers(ctx)
+
+	return cc.result(count), err
+}
+
+// Close closes the Docker client when possible.
+func (cc *containersCheck) Close() error {
+	if closer, ok := cc.client.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("close docker client: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Check implements the check.Check interface by returning the most recent background result.
 func (dc *dockerCheck) Check(ctx context.Context) error {
+	_, err := dc.CheckResult(ctx)
+
+	return err
+}
+
+// CheckResult implements the check.ResultCheck interface. The number of containers
+// matching the labels is reported as the observed value.
+func (dc *dockerCheck) CheckResult(ctx context.Context) (check.Result, error) {
 	select {
 	case <-ctx.Done():
-		return fmt.Errorf("docker check context: %w", ctx.Err())
+		return check.Result{}, fmt.Errorf("docker check context: %w", ctx.Err())
 	default:
 	}
 
 	dc.mu.RLock()
 	lastErr := dc.lastErr
+	lastCount := dc.lastCount
 	lastChecked := dc.lastChecked
 	dc.mu.RUnlock()
 
 	if lastChecked.IsZero() {
-		return errors.New("docker check has not completed an initial run")
+		return check.Result{}, errors.New("docker check has not completed an initial run")
 	}
 
 	if time.Since(lastChecked) > dc.interval*2 {
-		return fmt.Errorf("docker check result stale: last=%s interval=%s", time.Since(lastChecked), dc.interval)
+		return check.Result{}, fmt.Errorf("docker check result stale: last=%s interval=%s",
+			time.Since(lastChecked), dc.interval)
 	}
 
-	return lastErr
+	return dc.containers.result(lastCount), lastErr
 }
 
 // Close stops the background loop and closes the Docker client when possible.
@@ -188,11 +269,5 @@ func (dc *dockerCheck) Close() error {
 	dc.cancel()
 	dc.wg.Wait()
 
-	if closer, ok := dc.client.(io.Closer); ok {
-		if err := closer.Close(); err != nil {
-			return fmt.Errorf("close docker client: %w", err)
-		}
-	}
-
-	return nil
+	return dc.containers.Close()
 }
@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 )
 
 type stubDockerClient struct {
+	mu         sync.Mutex
 	containers []dockertypes.Container
 	err        error
 }
@@ -20,6 +22,9 @@ func (s *stubDockerClient) ContainerList(
 	_ context.Context,
 	_ containerTypes.ListOptions,
 ) ([]dockertypes.Container, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.err != nil {
 		return nil, s.err
 	}
@@ -27,6 +32,13 @@ func (s *stubDockerClient) ContainerList(
 	return append([]dockertypes.Container(nil), s.containers...), nil
 }
 
+func (s *stubDockerClient) setContainers(containers []dockertypes.Container) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.containers = containers
+}
+
 func TestCheck_HealthyContainers(t *testing.T) {
 	t.Parallel()
 
@@ -47,6 +59,14 @@ func TestCheck_HealthyContainers(t *testing.T) {
 	if err := checker.Check(context.Background()); err != nil {
 		t.Fatalf("expected healthy check, got error: %v", err)
 	}
+
+	result, err := check.Run(context.Background(), checker)
+	if err != nil {
+		t.Fatalf("expected healthy check, got error: %v", err)
+	}
+	if result.ObservedValue != 2 || result.Unit != "containers" {
+		t.Fatalf("expected 2 containers to be reported, got %v %s", result.ObservedValue, result.Unit)
+	}
 }
 
 func TestCheck_FailsWhenNoContainersMatch(t *testing.T) {
@@ -84,9 +104,9 @@ func TestCheck_DetectsContainersStopping(t *testing.T) {
 
 	waitForHealthy(t, checker)
 
-	client.containers = []dockertypes.Container{
+	client.setContainers([]dockertypes.Container{
 		{ID: "abc", Labels: labels, State: "exited"},
-	}
+	})
 
 	waitForFailure(t, checker)
 }
@@ -137,3 +157,37 @@ func waitForFailure(t *testing.T, checker check.Check) {
 
 	t.Fatalf("checker did not report failure before deadline")
 }
+
+func TestOnDemandCheck_ListsContainersOnEveryRun(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"app": "status"}
+	client := &stubDockerClient{
+		containers: []dockertypes.Container{
+			{ID: "abc", Labels: labels, State: "running"},
+		},
+	}
+
+	checker, err := newContainersCheck(labels, check.Config{Timeout: 50 * time.Millisecond}, client)
+	if err != nil {
+		t.Fatalf("unexpected error creating check: %v", err)
+	}
+	defer checker.Close()
+
+	result, err := check.Run(context.Background(), checker)
+	if err != nil || result.ObservedValue != 1 {
+		t.Fatalf("expected 1 running container, got %v, %v", result.ObservedValue, err)
+	}
+
+	client.setContainers([]dockertypes.Container{
+		{ID: "abc", Labels: labels, State: "exited"},
+	})
+
+	if err := checker.Check(context.Background()); err == nil {
+		t.Fatal("expected the stopped container to be reported on the next run")
+	}
+
+	if _, err := newContainersCheck(nil, check.Config{}, client); err == nil {
+		t.Fatal("expected error for empty labels")
+	}
+}
@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"runtime"
 	"syscall"
@@ -13,6 +14,8 @@ import (
 const (
 	percentMultiplier = 100
 	bytesPerGB        = 1024 * 1024 * 1024
+	// gbPrecision rounds reported sizes to two decimal places.
+	gbPrecision = 100
 )
 
 // CheckMemory creates a health check for memory usage.
@@ -31,21 +34,31 @@ func CheckMemory(maxUsagePercent float64) check.Check {
 	})
 }
 
-// CheckDiskSpace creates a health check for disk space.
+// CheckDiskSpace creates a health check for disk space. The free space is
+// reported in GB as the observed value.
 func CheckDiskSpace(path string, minFreeSpaceGB float64) check.Check {
-	return check.CheckFunc(func(_ context.Context) error {
+	return check.ResultCheckFunc(func(_ context.Context) (check.Result, error) {
 		var stat syscall.Statfs_t
 		if err := syscall.Statfs(path, &stat); err != nil {
-			return fmt.Errorf("failed to get disk stats: %w", err)
+			return check.Result{}, fmt.Errorf("failed to get disk stats: %w", err)
 		}
 
 		freeSpaceGB := float64(stat.Bavail*uint64(stat.Bsize)) / bytesPerGB
+		result := check.Result{
+			ObservedValue: roundGB(freeSpaceGB),
+			Unit:          "GB",
+			Measurement:   "freeSpace",
+			Details: map[string]any{
+				"path":     path,
+				"total_gb": roundGB(float64(stat.Blocks*uint64(stat.Bsize)) / bytesPerGB),
+			},
+		}
 		if freeSpaceGB < minFreeSpaceGB {
-			return fmt.Errorf("insufficient disk space: %.2f GB free (minimum: %.2f GB)",
+			return result, fmt.Errorf("insufficient disk space: %.2f GB free (minimum: %.2f GB)",
 				freeSpaceGB, minFreeSpaceGB)
 		}
 
-		return nil
+		return result, nil
 	})
 }
 
@@ -65,3 +78,8 @@ func CheckFile(path string, requiredPerm os.FileMode) check.Check {
 		return nil
 	})
 }
+
+// roundGB rounds a size in GB for reporting.
+func roundGB(gb float64) float64 {
+	return math.Round(gb*gbPrecision) / gbPrecision
+}
@@ -0,0 +1,149 @@
+// Command statusd is a self-hosted status monitor. It checks the targets of a
+// configuration file (see package config) in the background and serves:
+//
+//	/status          status page
+//	/health          JSON results, 500 when a high importance target fails
+//	/health/json     application/health+json results
+//	/health/live     Kubernetes liveness probe
+//	/health/ready    Kubernetes readiness probe
+//	/health/startup  Kubernetes startup probe
+//	/metrics         Prometheus metrics
+//	/admin/          drain, overrides and maintenance windows (see status.HealthChecker.AdminHandler),
+//	                 when the STATUSD_ADMIN_TOKEN environment variable is set to the bearer token
+//
+// Send SIGHUP to reload the configuration file and SIGINT or SIGTERM to shut down gracefully.
+// With -drain-delay, statusd drains first: /health and /health/ready respond with 503 for
+// the given time so that load balancers stop sending traffic before the listener closes.
+//
+// Usage:
+//
+//	statusd -config status.yaml [-addr :8080] [-drain-delay 0s] [-shutdown-timeout 10s]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/config"
+)
+
+const (
+	defaultAddr            = ":8080"
+	defaultShutdownTimeout = 10 * time.Second
+	readHeaderTimeout      = 10 * time.Second
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		slog.Error("statusd failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("statusd", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the YAML or JSON configuration file (required)")
+	addr := flags.String("addr", defaultAddr, "address to listen on")
+	shutdownTimeout := flags.Duration("shutdown-timeout", defaultShutdownTimeout,
+		"time to wait for in-flight requests on shutdown")
+	drainDelay := flags.Duration("drain-delay", 0,
+		"time to report unavailable to load balancers before shutting down")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *configPath == "" {
+		flags.Usage()
+
+		return errors.New("-config is required")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	srv := newServer(*configPath, config.DefaultRegistry(), os.Getenv("STATUSD_ADMIN_TOKEN"))
+	if err := srv.load(ctx); err != nil {
+		return err
+	}
+	defer srv.close()
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	return serve(ctx, srv, httpServer, *drainDelay, *shutdownTimeout)
+}
+
+// serve serves HTTP requests until ctx is done, reloading the configuration of
+// srv on SIGHUP. On shutdown, srv reports unavailable for drainDelay before the
+// HTTP server waits up to shutdownTimeout for in-flight requests.
+func serve(
+	ctx context.Context,
+	srv *server,
+	httpServer *http.Server,
+	drainDelay, shutdownTimeout time.Duration,
+) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "addr", httpServer.Addr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	for {
+		select {
+		case err := <-serveErr:
+			return fmt.Errorf("serve: %w", err)
+		case <-reload:
+			if err := srv.load(ctx); err != nil {
+				slog.Error("reloading configuration, keeping the previous one", "error", err)
+			}
+		case <-ctx.Done():
+			return shutdown(srv, httpServer, drainDelay, shutdownTimeout)
+		}
+	}
+}
+
+// shutdown drains srv for drainDelay and gracefully shuts the HTTP server down.
+func shutdown(srv *server, httpServer *http.Server, drainDelay, shutdownTimeout time.Duration) error {
+	if drainDelay > 0 {
+		slog.Info("draining", "delay", drainDelay)
+		srv.drain()
+		time.Sleep(drainDelay)
+	}
+
+	slog.Info("shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	return nil
+}
+
+// serviceInfo describes statusd in the application/health+json response.
+func serviceInfo() status.ServiceInfo {
+	info := status.ServiceInfo{ServiceID: "statusd", Description: "status monitor"}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Version = build.Main.Version
+	}
+
+	return info
+}
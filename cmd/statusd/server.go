@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/config"
+)
+
+// server serves the endpoints of the instance built from the configuration file
+// and swaps it for a new one on reload.
+type server struct {
+	path       string
+	registry   *config.Registry
+	adminToken string
+
+	mu       sync.Mutex
+	instance *config.Instance
+	handler  atomic.Pointer[http.ServeMux]
+}
+
+func newServer(path string, registry *config.Registry, adminToken string) *server {
+	return &server{path: path, registry: registry, adminToken: adminToken}
+}
+
+// load reads the configuration file and starts checking its targets in the
+// background. The running instance keeps serving until the new one has completed
+// its initial checks, and is kept when the configuration is invalid. The state
+// set through the admin endpoints is carried over to the new instance.
+func (s *server) load(ctx context.Context) error {
+	cfg, err := config.Load(s.path)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	instance, err := cfg.Build(s.registry)
+	if err != nil {
+		return fmt.Errorf("build config: %w", err)
+	}
+
+	if err := instance.Checker.Start(ctx); err != nil {
+		instance.Close()
+
+		return fmt.Errorf("start health checker: %w", err)
+	}
+
+	s.mu.Lock()
+	previous := s.instance
+	if previous != nil {
+		carryOver(previous.Checker, instance.Checker, cfg)
+	}
+	s.instance = instance
+	s.handler.Store(newMux(instance, s.adminToken))
+	s.mu.Unlock()
+
+	if previous != nil {
+		stop(previous)
+	}
+
+	slog.Info("configuration loaded", "path", s.path, "targets", len(cfg.Targets))
+
+	return nil
+}
+
+// carryOver copies the drain state, overrides and maintenance windows of the
+// previous checker to the reloaded one. Overrides and windows of targets and
+// groups that are no longer configured are dropped.
+func carryOver(previous, next *status.HealthChecker, cfg *config.Config) {
+	if previous.Draining() {
+		next.Drain()
+	}
+
+	for target, forced := range previous.Overrides() {
+		if err := next.ForceStatus(target, forced); err != nil {
+			slog.Warn("dropping override on reload", "target", target, "error", err)
+		}
+	}
+
+	targets := make(map[string]bool, len(cfg.Targets))
+	groups := make(map[string]bool)
+	for _, target := range cfg.Targets {
+		targets[target.Name] = true
+		if target.Group != "" {
+			groups[target.Group] = true
+		}
+	}
+
+	for _, window := range previous.Maintenance() {
+		if window.Target != "" && !targets[window.Target] || window.Group != "" && !groups[window.Group] {
+			slog.Warn("dropping maintenance window on reload",
+				"id", window.ID, "target", window.Target, "group", window.Group)
+
+			continue
+		}
+		if _, err := next.AddMaintenance(window); err != nil {
+			slog.Warn("dropping maintenance window on reload", "id", window.ID, "error", err)
+		}
+	}
+}
+
+// drain makes the running instance report itself unavailable to load balancers.
+func (s *server) drain() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.instance != nil {
+		s.instance.Checker.Drain()
+	}
+}
+
+// close stops the running instance.
+func (s *server) close() {
+	s.mu.Lock()
+	instance := s.instance
+	s.instance = nil
+	s.mu.Unlock()
+
+	if instance != nil {
+		stop(instance)
+	}
+}
+
+// ServeHTTP implements http.Handler with the endpoints of the current instance.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mux := s.handler.Load()
+	if mux == nil {
+		http.Error(w, "configuration not loaded", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	mux.ServeHTTP(w, r)
+}
+
+// newMux registers the endpoints of the instance. The admin endpoints are only
+// registered when an admin token is set.
+func newMux(instance *config.Instance, adminToken string) *http.ServeMux {
+	checker := instance.Checker
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", checker.Handler())
+	mux.Handle("/health/json", checker.HealthJSONHandler(serviceInfo()))
+	mux.Handle("/health/live", checker.LivenessHandler())
+	mux.Handle("/health/ready", checker.ReadinessHandler())
+	mux.Handle("/health/startup", checker.StartupHandler())
+	mux.Handle("/metrics", checker.MetricsHandler())
+	mux.Handle("/status", instance.Page.Handler())
+	mux.Handle("/{$}", http.RedirectHandler("/status", http.StatusFound))
+	if adminToken != "" {
+		mux.Handle("/admin/", http.StripPrefix("/admin", checker.AdminHandler(status.BearerToken(adminToken))))
+	}
+
+	return mux
+}
+
+// stop terminates the background checks of the instance and releases its resources.
+func stop(instance *config.Instance) {
+	instance.Checker.Stop()
+	if err := instance.Close(); err != nil {
+		slog.Warn("closing checks", "error", err)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/config"
+)
+
+func TestServer_LoadAndReload(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	path := filepath.Join(t.TempDir(), "status.yaml")
+	writeConfig := func(name string) {
+		t.Helper()
+
+		data := "targets:\n  - name: " + name + "\n    type: tcp\n" +
+			"    params:\n      host: " + host + "\n      port: " + port + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	srv := newServer(path, config.DefaultRegistry(), "")
+	defer srv.close()
+
+	if code, _ := get(srv, "/health"); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 before the configuration is loaded, got %d", code)
+	}
+
+	writeConfig("Database")
+	if err := srv.load(context.Background()); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	for _, endpoint := range []string{"/health", "/health/json", "/health/ready", "/metrics", "/status"} {
+		code, body := get(srv, endpoint)
+		if code != http.StatusOK || !strings.Contains(body, "Database") {
+			t.Fatalf("unexpected response of %s: %d %s", endpoint, code, body)
+		}
+	}
+
+	writeConfig("Cache")
+	if err := srv.load(context.Background()); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, body := get(srv, "/health"); !strings.Contains(body, "Cache") || strings.Contains(body, "Database") {
+		t.Fatalf("expected reloaded targets, got %s", body)
+	}
+
+	if err := os.WriteFile(path, []byte("targets:\n  - name: Broken\n    type: nope\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := srv.load(context.Background()); err == nil {
+		t.Fatal("expected error for invalid configuration")
+	}
+	if _, body := get(srv, "/health"); !strings.Contains(body, "Cache") {
+		t.Fatalf("expected previous configuration to be kept, got %s", body)
+	}
+}
+
+func get(handler http.Handler, path string) (int, string) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return w.Code, w.Body.String()
+}
+
+func TestServer_AdminAndDrain(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "status.yaml")
+	data := "targets:\n  - name: Config\n    type: file\n    params:\n      path: " + path + "\n      perm: \"0600\"\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	srv := newServer(path, config.DefaultRegistry(), "secret")
+	defer srv.close()
+
+	if err := srv.load(context.Background()); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if code, _ := get(srv, "/admin/"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/drain", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 from drain, got %d %s", w.Code, w.Body.String())
+	}
+
+	if err := srv.load(context.Background()); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	for _, endpoint := range []string{"/health", "/health/ready"} {
+		if code, _ := get(srv, endpoint); code != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503 from %s while draining after reload, got %d", endpoint, code)
+		}
+	}
+}
+
+func TestServer_ReloadKeepsAdminState(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "status.yaml")
+	target := func(name string) string {
+		return "  - name: " + name + "\n    type: file\n    params:\n      path: " + path + "\n      perm: \"0600\"\n"
+	}
+	writeConfig := func(data string) {
+		t.Helper()
+
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	writeConfig("targets:\n" + target("Config") + target("Legacy"))
+	srv := newServer(path, config.DefaultRegistry(), "secret")
+	defer srv.close()
+
+	admin := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+
+		return w
+	}
+
+	if err := srv.load(context.Background()); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	ids := make(map[string]string)
+	for _, name := range []string{"Config", "Legacy"} {
+		if w := admin(http.MethodPut, "/admin/overrides?target="+name+"&status=fail", ""); w.Code != http.StatusNoContent {
+			t.Fatalf("expected 204 from override, got %d %s", w.Code, w.Body.String())
+		}
+		window := `{"target":"` + name + `","start":"2024-01-07T02:00:00Z","end":"2999-01-07T03:00:00Z"}`
+		w := admin(http.MethodPost, "/admin/maintenance", window)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201 from maintenance, got %d %s", w.Code, w.Body.String())
+		}
+		var created status.MaintenanceWindow
+		if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+			t.Fatalf("decode window: %v", err)
+		}
+		ids[name] = created.ID
+	}
+
+	writeConfig("targets:\n" + target("Config"))
+	if err := srv.load(context.Background()); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	checker := srv.instance.Checker
+	if overrides := checker.Overrides(); len(overrides) != 1 || overrides["Config"] != status.HealthTargetStatusFail {
+		t.Fatalf("expected only the Config override after reload, got %v", overrides)
+	}
+	if kept := checker.Maintenance(); len(kept) != 1 || kept[0].ID != ids["Config"] || kept[0].Target != "Config" {
+		t.Fatalf("expected only the Config window after reload, got %+v", kept)
+	}
+}
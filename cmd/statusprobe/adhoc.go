@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/alarmistdev/status/config"
+)
+
+// adhocParams returns the param names of the positional arguments of every
+// ad-hoc check type. The "address" pseudo param is split into host and port.
+func adhocParams() map[string][]string {
+	return map[string][]string{
+		"http":         {"method", "url", "expected_status"},
+		"graphql":      {"method", "url", "expected_status"},
+		"tcp":          {"address"},
+		"udp":          {"address"},
+		"grpc":         {"addr", "service"},
+		"latency":      {"address", "max_latency"},
+		"dns":          {"host"},
+		"icmp":         {"host"},
+		"postgres":     {"dsn"},
+		"mysql":        {"dsn"},
+		"redis":        {"addr", "username", "password"},
+		"nats":         {"url"},
+		"rabbitmq":     {"url"},
+		"kafka-topics": {"brokers"},
+		"memory":       {"max_usage_percent"},
+		"disk":         {"path", "min_free_gb"},
+		"file":         {"path", "perm"},
+		"process":      {"name"},
+		"remote":       {"url"},
+	}
+}
+
+// adhocConfig builds a single target configuration from positional arguments,
+// such as "tcp db:5432" or "http GET https://example.com 200".
+func adhocConfig(args []string) (*config.Config, error) {
+	typ, values := args[0], args[1:]
+
+	names, ok := adhocParams()[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown check type %q", typ)
+	}
+
+	// The method of http checks is optional.
+	if (typ == "http" || typ == "graphql") && len(values) > 0 && strings.Contains(values[0], "://") {
+		names = names[1:]
+	}
+
+	if len(values) == 0 || len(values) > len(names) {
+		return nil, fmt.Errorf("usage: statusprobe %s %s", typ, strings.Join(names, " "))
+	}
+
+	params := make(map[string]any, len(values))
+	for i, value := range values {
+		switch name := names[i]; name {
+		case "address":
+			host, port, err := net.SplitHostPort(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q: %w", value, err)
+			}
+			params["host"] = host
+			params["port"] = parseValue(port)
+		case "brokers":
+			brokers := make([]any, 0)
+			for _, broker := range strings.Split(value, ",") {
+				brokers = append(brokers, broker)
+			}
+			params[name] = brokers
+		case "expected_status", "max_usage_percent", "min_free_gb":
+			params[name] = parseValue(value)
+		default:
+			params[name] = value
+		}
+	}
+
+	cfg := &config.Config{
+		Targets: []config.Target{{Name: typ, Type: typ, Params: params}},
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid check: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// parseValue converts a numeric argument into a number and returns others unchanged,
+// so that malformed numbers are reported by the param validation of the check.
+func parseValue(value string) any {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+
+	return value
+}
@@ -0,0 +1,226 @@
+// Command statusprobe runs health checks once and reports the outcome following
+// the Nagios plugin conventions, so that cron jobs, Docker HEALTHCHECK directives
+// and Icinga share the check logic of services using this module.
+//
+// It checks either every target of a configuration file (see package config) or
+// a single ad-hoc check:
+//
+//	statusprobe -config status.yaml
+//	statusprobe tcp db:5432
+//	statusprobe http GET https://example.com/health 200
+//	statusprobe -json disk /var 10
+//
+// The exit code is 0 (OK) when all targets pass, 1 (WARNING) when a target is
+// degraded or a low importance target fails, 2 (CRITICAL) when a high importance
+// target fails and 3 (UNKNOWN) when the checks could not be run. The first line of
+// the human output contains the check durations as performance data.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/config"
+)
+
+// Exit codes of the Nagios plugin API.
+const (
+	exitOK       = 0
+	exitWarning  = 1
+	exitCritical = 2
+	exitUnknown  = 3
+)
+
+const defaultTimeout = 10 * time.Second
+
+// errUsage is returned when neither a configuration file nor an ad-hoc check is given.
+var errUsage = errors.New("either -config or a check type is required")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// jsonReport is the output of the -json flag.
+type jsonReport struct {
+	Status   string                     `json:"status"`
+	ExitCode int                        `json:"exit_code"`
+	Error    string                     `json:"error,omitempty"`
+	Results  []status.HealthCheckResult `json:"results,omitempty"`
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("statusprobe", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "", "path to a YAML or JSON configuration file")
+	jsonOutput := flags.Bool("json", false, "print a JSON report instead of the Nagios plugin output")
+	timeout := flags.Duration("timeout", defaultTimeout, "overall timeout of the checks")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: statusprobe [flags] -config FILE\n       statusprobe [flags] TYPE ARGS...\n\n")
+		fmt.Fprintf(stderr, "types: %s\n\nflags:\n", strings.Join(adhocTypes(), ", "))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUnknown
+	}
+
+	results, err := probe(*configPath, flags.Args(), *timeout)
+	if err != nil {
+		if !*jsonOutput && errors.Is(err, errUsage) {
+			flags.Usage()
+		}
+
+		return report(stdout, *jsonOutput, exitUnknown, nil, err)
+	}
+
+	return report(stdout, *jsonOutput, exitCode(results), results, nil)
+}
+
+// probe runs the checks of the configuration file or the ad-hoc check once.
+func probe(configPath string, args []string, timeout time.Duration) ([]status.HealthCheckResult, error) {
+	var (
+		cfg *config.Config
+		err error
+	)
+	switch {
+	case configPath != "" && len(args) == 0:
+		cfg, err = config.Load(configPath)
+	case configPath == "" && len(args) > 0:
+		cfg, err = adhocConfig(args)
+	default:
+		return nil, errUsage
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := cfg.Build(config.DefaultRegistry(), status.WithCheckTimeout(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("build checks: %w", err)
+	}
+	defer instance.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	results, err := instance.Checker.Check(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("run checks: %w", err)
+	}
+
+	return results, nil
+}
+
+// exitCode maps the results to a Nagios plugin exit code.
+func exitCode(results []status.HealthCheckResult) int {
+	code := exitOK
+	for _, result := range results {
+		switch {
+		case result.Status == status.HealthTargetStatusFail && result.Target.Importance == status.TargetImportanceHigh:
+			return exitCritical
+		case result.Status != status.HealthTargetStatusOk:
+			code = exitWarning
+		}
+	}
+
+	return code
+}
+
+// report prints the outcome and returns the exit code.
+func report(w io.Writer, asJSON bool, code int, results []status.HealthCheckResult, err error) int {
+	if asJSON {
+		out := jsonReport{Status: statusName(code), ExitCode: code, Results: results}
+		if err != nil {
+			out.Error = err.Error()
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			return exitUnknown
+		}
+
+		return code
+	}
+
+	if err != nil {
+		fmt.Fprintf(w, "STATUS %s - %v\n", statusName(code), err)
+
+		return code
+	}
+
+	fmt.Fprintf(w, "STATUS %s - %s | %s\n", statusName(code), summary(results), perfdata(results))
+	for _, result := range results {
+		line := fmt.Sprintf("%s: %s", result.Target.Name, result.Status)
+		if result.ErrorMessage != "" {
+			line += " (" + result.ErrorMessage + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	return code
+}
+
+// summary describes the results in a single line.
+func summary(results []status.HealthCheckResult) string {
+	var unhealthy []string
+	for _, result := range results {
+		if result.Status != status.HealthTargetStatusOk {
+			unhealthy = append(unhealthy, result.Target.Name)
+		}
+	}
+
+	if len(unhealthy) == 0 {
+		return fmt.Sprintf("%d of %d targets ok", len(results), len(results))
+	}
+
+	return fmt.Sprintf("%d of %d targets not ok: %s", len(unhealthy), len(results), strings.Join(unhealthy, ", "))
+}
+
+// perfdata formats the check durations as Nagios performance data.
+func perfdata(results []status.HealthCheckResult) string {
+	values := make([]string, 0, len(results))
+	for _, result := range results {
+		label := strings.ReplaceAll(result.Target.Name, "'", "''")
+		seconds := strconv.FormatFloat(result.Duration.Seconds(), 'f', -1, 64)
+		values = append(values, fmt.Sprintf("'%s'=%ss;;;0", label, seconds))
+	}
+
+	return strings.Join(values, " ")
+}
+
+// statusName returns the Nagios service state of the exit code.
+func statusName(code int) string {
+	switch code {
+	case exitOK:
+		return "OK"
+	case exitWarning:
+		return "WARNING"
+	case exitCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// adhocTypes returns the check types supported as ad-hoc checks.
+func adhocTypes() []string {
+	types := make([]string, 0, len(adhocParams()))
+	for typ := range adhocParams() {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	return types
+}
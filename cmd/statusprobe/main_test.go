@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_AdhocChecks(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expectedOut  string
+	}{
+		{
+			name:         "tcp ok",
+			args:         []string{"tcp", listener.Addr().String()},
+			expectedCode: exitOK,
+			expectedOut:  "STATUS OK - 1 of 1 targets ok | 'tcp'=",
+		},
+		{
+			name:         "tcp refused",
+			args:         []string{"tcp", closedAddr},
+			expectedCode: exitCritical,
+			expectedOut:  "STATUS CRITICAL - 1 of 1 targets not ok: tcp",
+		},
+		{
+			name:         "http with method and status",
+			args:         []string{"http", "GET", server.URL, "202"},
+			expectedCode: exitOK,
+			expectedOut:  "http: ok",
+		},
+		{
+			name:         "http with unexpected status",
+			args:         []string{"http", server.URL},
+			expectedCode: exitCritical,
+			expectedOut:  "unexpected status code: got 202, want 200",
+		},
+		{
+			name:         "unknown type",
+			args:         []string{"smtp", "mail:25"},
+			expectedCode: exitUnknown,
+			expectedOut:  `STATUS UNKNOWN - unknown check type "smtp"`,
+		},
+		{
+			name:         "missing arguments",
+			args:         []string{},
+			expectedCode: exitUnknown,
+			expectedOut:  "STATUS UNKNOWN - either -config or a check type is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+
+			if code != tt.expectedCode {
+				t.Fatalf("expected exit code %d, got %d: %s", tt.expectedCode, code, stdout.String())
+			}
+			if !strings.Contains(stdout.String(), tt.expectedOut) {
+				t.Fatalf("expected output to contain %q, got %q", tt.expectedOut, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_ConfigWithJSONOutput(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	config := "targets:\n" +
+		"  - name: Database\n    type: tcp\n    params: {host: " + host + ", port: " + port + "}\n" +
+		"  - name: Cache\n    type: tcp\n    importance: low\n    params: {host: " + host + ", port: 1}\n"
+	path := filepath.Join(t.TempDir(), "status.yaml")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-json", "-config", path}, &stdout, &stderr)
+	if code != exitWarning {
+		t.Fatalf("expected warning exit code, got %d: %s", code, stdout.String())
+	}
+
+	var report struct {
+		Status   string `json:"status"`
+		ExitCode int    `json:"exit_code"`
+		Results  []struct {
+			Status string `json:"status"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+
+	if report.Status != "WARNING" || report.ExitCode != exitWarning || len(report.Results) != 2 ||
+		report.Results[0].Status != "ok" || report.Results[1].Status != "fail" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
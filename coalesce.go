@@ -0,0 +1,118 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// inflight is an inline run of the checks shared by concurrent callers.
+type inflight struct {
+	done    chan struct{}
+	results []HealthCheckResult
+	err     error
+	// aborted is set when the context of the caller executing the run was done,
+	// in which case the results carry its cancellation and are not shared.
+	aborted bool
+	// waiters counts the callers that joined the run. It is guarded by flightMu.
+	waiters int
+}
+
+// ttlEntry is a completed inline run kept for WithResultTTL.
+type ttlEntry struct {
+	results   []HealthCheckResult
+	startedAt time.Time
+}
+
+// WithResultTTL makes Check serve the results of the last inline run while they
+// are younger than ttl instead of executing the checks again. It has no effect in
+// background mode, where results are always served from the scheduler.
+func WithResultTTL(ttl time.Duration) CheckerOption {
+	return func(c *HealthChecker) {
+		c.resultTTL = ttl
+	}
+}
+
+// coalescedCheck runs the checks of the targets participating in the given probe
+// inline. Concurrent callers share a single in-flight run executed with the context
+// of the first caller, and results younger than the configured TTL are served
+// without running the checks again.
+//
+// Callers waiting for a run started by someone else stop waiting when their ctx
+// is done. When the context of the first caller is done before the run completes,
+// waiting callers run the checks again with their own context.
+func (c *HealthChecker) coalescedCheck(ctx context.Context, probe Probe) ([]HealthCheckResult, error) {
+	for {
+		if results, ok := c.ttlResults(probe); ok {
+			return results, nil
+		}
+
+		c.flightMu.Lock()
+		if call, ok := c.flights[probe]; ok {
+			call.waiters++
+			c.flightMu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("waiting for health check: %w", ctx.Err())
+			case <-call.done:
+			}
+
+			if call.aborted {
+				continue
+			}
+			if call.err != nil {
+				return nil, call.err
+			}
+
+			return slices.Clone(call.results), nil
+		}
+
+		call := &inflight{done: make(chan struct{})}
+		if c.flights == nil {
+			c.flights = make(map[Probe]*inflight)
+		}
+		c.flights[probe] = call
+		c.flightMu.Unlock()
+
+		startedAt := time.Now()
+		results, err := c.checkTargets(ctx, probe)
+
+		call.results = results
+		call.err = err
+		call.aborted = ctx.Err() != nil
+
+		c.flightMu.Lock()
+		delete(c.flights, probe)
+		if c.resultTTL > 0 && err == nil && !call.aborted {
+			if c.ttlCache == nil {
+				c.ttlCache = make(map[Probe]ttlEntry)
+			}
+			c.ttlCache[probe] = ttlEntry{results: results, startedAt: startedAt}
+		}
+		c.flightMu.Unlock()
+
+		close(call.done)
+
+		return slices.Clone(results), err
+	}
+}
+
+// ttlResults returns a copy of the cached results of the given probe when they
+// are younger than the configured TTL.
+func (c *HealthChecker) ttlResults(probe Probe) ([]HealthCheckResult, bool) {
+	if c.resultTTL <= 0 {
+		return nil, false
+	}
+
+	c.flightMu.Lock()
+	defer c.flightMu.Unlock()
+
+	entry, ok := c.ttlCache[probe]
+	if !ok || time.Since(entry.startedAt) >= c.resultTTL {
+		return nil, false
+	}
+
+	return slices.Clone(entry.results), true
+}
@@ -0,0 +1,196 @@
+package status
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+// waitForWaiters waits until n callers joined the in-flight run of all targets.
+func waitForWaiters(t *testing.T, checker *HealthChecker, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		checker.flightMu.Lock()
+		call, ok := checker.flights[""]
+		joined := ok && call.waiters >= n
+		checker.flightMu.Unlock()
+
+		if joined {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d callers to join the in-flight run", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHealthChecker_Check_CoalescesConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	checker := NewHealthChecker().
+		WithTarget("slow", check.CheckFunc(func(ctx context.Context) error {
+			if calls.Add(1) == 1 {
+				close(started)
+			}
+			<-release
+
+			return nil
+		}))
+
+	var wg sync.WaitGroup
+	results := make([][]HealthCheckResult, 5)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _ = checker.Check(context.Background())
+	}()
+	<-started
+
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = checker.Check(context.Background())
+		}()
+	}
+
+	waitForWaiters(t, checker, len(results)-1)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected a single run, got %d", got)
+	}
+	for i, result := range results {
+		if len(result) != 1 || result[0].Status != HealthTargetStatusOk {
+			t.Fatalf("unexpected results of caller %d: %+v", i, result)
+		}
+	}
+}
+
+func TestHealthChecker_Check_FollowerStopsWaitingOnContext(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	checker := NewHealthChecker().
+		WithTarget("slow", check.CheckFunc(func(ctx context.Context) error {
+			close(started)
+			<-release
+
+			return nil
+		}))
+
+	go func() { _, _ = checker.Check(context.Background()) }()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := checker.Check(ctx); err == nil {
+		t.Fatal("expected error when the context of a waiting caller is done")
+	}
+}
+
+func TestHealthChecker_Check_RerunsWhenLeaderAborted(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	started := make(chan struct{})
+
+	checker := NewHealthChecker().
+		WithTarget("ctx", check.CheckFunc(func(ctx context.Context) error {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-ctx.Done()
+
+				return ctx.Err()
+			}
+
+			return nil
+		}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { _, _ = checker.Check(ctx) }()
+	<-started
+
+	done := make(chan []HealthCheckResult)
+	go func() {
+		results, _ := checker.Check(context.Background())
+		done <- results
+	}()
+
+	waitForWaiters(t, checker, 1)
+	cancel()
+
+	results := <-done
+	if results[0].Status != HealthTargetStatusOk {
+		t.Fatalf("expected the follower to run the checks again, got %+v", results[0])
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 runs, got %d", got)
+	}
+}
+
+func TestHealthChecker_WithResultTTL(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	checker := NewHealthChecker(WithResultTTL(50*time.Millisecond)).
+		WithTarget("counter", check.CheckFunc(func(ctx context.Context) error {
+			calls.Add(1)
+
+			return nil
+		}))
+
+	for range 3 {
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("check: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected results to be served from cache, got %d runs", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected expired results to be refreshed, got %d runs", got)
+	}
+}
+
+func TestHealthChecker_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	checker := (&HealthChecker{resultTTL: time.Minute}).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+	for range 2 {
+		results, err := checker.Check(context.Background())
+		if err != nil {
+			t.Fatalf("check: %v", err)
+		}
+		if len(results) != 1 || results[0].Status != HealthTargetStatusOk {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	}
+}
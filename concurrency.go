@@ -0,0 +1,101 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// semaphore limits the number of concurrently running checks. A nil semaphore is unlimited.
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+
+	return make(semaphore, n)
+}
+
+// acquire blocks until a slot is free or ctx is done.
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for a free check slot: %w", ctx.Err())
+	}
+}
+
+// release frees a slot taken by acquire.
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// WithMaxConcurrency limits the number of target checks running at the same time,
+// both for inline checks and in background mode. Checks over the limit wait for a
+// free slot. By default all targets are checked at once.
+func WithMaxConcurrency(n int) CheckerOption {
+	return func(c *HealthChecker) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithGroupConcurrency limits the number of checks of targets in the given group
+// (see WithGroup) running at the same time. It applies in addition to WithMaxConcurrency,
+// so that targets sharing infrastructure can be limited without slowing down the rest.
+func WithGroupConcurrency(group string, n int) CheckerOption {
+	return func(c *HealthChecker) {
+		if c.groupConcurrency == nil {
+			c.groupConcurrency = make(map[string]int)
+		}
+		c.groupConcurrency[group] = n
+	}
+}
+
+// initLimits creates the semaphores for the configured concurrency limits.
+func (c *HealthChecker) initLimits() {
+	c.limit = newSemaphore(c.maxConcurrency)
+	c.groupLimits = make(map[string]semaphore, len(c.groupConcurrency))
+	for group, n := range c.groupConcurrency {
+		c.groupLimits[group] = newSemaphore(n)
+	}
+}
+
+// acquire waits until the target may be checked without exceeding the concurrency
+// limits. The group slot is taken first, so that targets waiting on a busy group do
+// not hold slots of the overall limit. The returned function frees the slots.
+func (c *HealthChecker) acquire(ctx context.Context, target HealthTarget) (func(), error) {
+	group := c.groupLimits[target.Group]
+	if err := group.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := c.limit.acquire(ctx); err != nil {
+		group.release()
+
+		return nil, err
+	}
+
+	return func() {
+		c.limit.release()
+		group.release()
+	}, nil
+}
+
+// limitedResult returns the failed result of a target that could not acquire a check slot.
+func limitedResult(target HealthTarget, err error) HealthCheckResult {
+	return HealthCheckResult{
+		Target:       target,
+		Status:       HealthTargetStatusFail,
+		err:          err,
+		ErrorMessage: err.Error(),
+		CheckedAt:    time.Now(),
+	}
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+// concurrencyTracker records the peak number of checks running at once.
+type concurrencyTracker struct {
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (tr *concurrencyTracker) check() check.Check {
+	return check.CheckFunc(func(ctx context.Context) error {
+		running := tr.running.Add(1)
+		defer tr.running.Add(-1)
+
+		for {
+			peak := tr.peak.Load()
+			if running <= peak || tr.peak.CompareAndSwap(peak, running) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		return nil
+	})
+}
+
+func TestHealthChecker_WithMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var tracker concurrencyTracker
+	checker := NewHealthChecker(WithMaxConcurrency(3))
+	for i := range 10 {
+		checker.WithTarget(fmt.Sprintf("target-%d", i), tracker.check())
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if peak := tracker.peak.Load(); peak > 3 {
+		t.Fatalf("expected at most 3 concurrent checks, got %d", peak)
+	}
+	for i, result := range results {
+		if want := fmt.Sprintf("target-%d", i); result.Target.Name != want {
+			t.Fatalf("expected result %d to be %s, got %s", i, want, result.Target.Name)
+		}
+		if result.Status != HealthTargetStatusOk {
+			t.Fatalf("unexpected status of %s: %s", result.Target.Name, result.Status)
+		}
+	}
+}
+
+func TestHealthChecker_WithGroupConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var databases, others concurrencyTracker
+	checker := NewHealthChecker(WithGroupConcurrency("Databases", 1))
+	for i := range 4 {
+		checker.WithTarget(fmt.Sprintf("db-%d", i), databases.check(), WithGroup("Databases"))
+		checker.WithTarget(fmt.Sprintf("api-%d", i), others.check(), WithGroup("APIs"))
+	}
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if peak := databases.peak.Load(); peak != 1 {
+		t.Fatalf("expected database checks to run one at a time, got %d", peak)
+	}
+	if peak := others.peak.Load(); peak < 2 {
+		t.Fatalf("expected other groups to run concurrently, got %d", peak)
+	}
+}
+
+func TestHealthChecker_WithMaxConcurrency_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithMaxConcurrency(1)).
+		WithTarget("waiting", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+	// Occupy the only slot.
+	release, err := checker.acquire(context.Background(), HealthTarget{})
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	results, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusFail || !strings.Contains(results[0].ErrorMessage, "free check slot") {
+		t.Fatalf("expected target waiting for a slot to fail, got %+v", results[0])
+	}
+	if entries, _ := checker.History("waiting"); len(entries) != 0 {
+		t.Fatalf("expected no history when the caller gave up waiting, got %+v", entries)
+	}
+}
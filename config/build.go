@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/check"
+)
+
+// Instance is a HealthChecker and status page built from a Config.
+type Instance struct {
+	Checker *status.HealthChecker
+	Page    *status.Page
+
+	closers []io.Closer
+}
+
+// Build validates the configuration against the registry and creates the
+// HealthChecker and Page it describes. The opts are applied after the checker
+// settings of the configuration. All invalid targets are reported together.
+func (c *Config) Build(registry *Registry, opts ...status.CheckerOption) (*Instance, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	checker := status.NewHealthChecker(append(c.checkerOptions(), opts...)...)
+	instance := &Instance{Checker: checker}
+
+	var errs []error
+	for _, target := range c.Targets {
+		targetCheck, err := registry.build(target, target.checkConfig())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("target %q: %w", target.Name, err))
+
+			continue
+		}
+		if closer, ok := targetCheck.(io.Closer); ok {
+			instance.closers = append(instance.closers, closer)
+		}
+
+		checker.WithTarget(target.Name, target.wrap(targetCheck), target.options()...)
+	}
+
+	if len(errs) > 0 {
+		instance.Close()
+
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, errors.Join(errs...))
+	}
+
+	pageOpts := []status.PageOption{
+		status.WithHealthChecker(checker),
+		status.WithVersion(c.ShowVersion),
+	}
+	if c.Title != "" {
+		pageOpts = append(pageOpts, status.WithTitle(c.Title))
+	}
+	for _, link := range c.Links {
+		pageOpts = append(pageOpts, status.WithLink(link.Name, link.URL))
+	}
+	instance.Page = status.NewPage(pageOpts...)
+
+	return instance, nil
+}
+
+// Close releases the resources held by checks, such as the background loops of
+// docker and kafka-ping targets. Stop the HealthChecker before closing.
+func (i *Instance) Close() error {
+	var errs []error
+	for _, closer := range i.closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close check: %w", err))
+		}
+	}
+	i.closers = nil
+
+	return errors.Join(errs...)
+}
+
+// checkerOptions converts the checker settings into HealthChecker options.
+func (c *Config) checkerOptions() []status.CheckerOption {
+	var opts []status.CheckerOption
+
+	if c.Checker.MaxConcurrency > 0 {
+		opts = append(opts, status.WithMaxConcurrency(c.Checker.MaxConcurrency))
+	}
+	if c.Checker.ResultTTL > 0 {
+		opts = append(opts, status.WithResultTTL(time.Duration(c.Checker.ResultTTL)))
+	}
+	if c.Checker.CheckTimeout > 0 {
+		opts = append(opts, status.WithCheckTimeout(time.Duration(c.Checker.CheckTimeout)))
+	}
+	if c.Checker.HistorySize > 0 {
+		opts = append(opts, status.WithHistorySize(c.Checker.HistorySize))
+	}
+
+	return opts
+}
+
+// checkConfig returns the check.Config passed to the factory of the target.
+func (t Target) checkConfig() check.Config {
+	config := check.DefaultConfig()
+	if t.Timeout > 0 {
+		config = config.WithTimeout(time.Duration(t.Timeout))
+	}
+	if t.Retries > 0 {
+		config = config.WithRetries(t.Retries)
+	}
+	if t.RetryDelay > 0 {
+		config = config.WithRetryDelay(time.Duration(t.RetryDelay))
+	}
+
+	return config
+}
+
+// wrap applies the timeout and retries of the target to its check. The timeout
+// limits every attempt.
+func (t Target) wrap(c check.Check) check.Check {
+	config := t.checkConfig()
+
+	if t.Timeout > 0 {
+		c = check.WithTimeout(c, config.Timeout)
+	}
+	if t.Retries > 0 {
+		c = check.WithRetries(c, t.Retries+1, config.RetryDelay)
+	}
+
+	return c
+}
+
+// options converts the target settings into HealthTarget options.
+func (t Target) options() []status.TargetOption {
+	var opts []status.TargetOption
+
+	if t.Importance != "" {
+		opts = append(opts, status.WithImportance(t.Importance))
+	}
+	if t.Group != "" {
+		opts = append(opts, status.WithGroup(t.Group))
+	}
+	if t.Icon != "" {
+		opts = append(opts, status.WithIcon(t.Icon))
+	}
+	if t.Interval > 0 {
+		opts = append(opts, status.WithInterval(time.Duration(t.Interval)))
+	}
+	if len(t.Probes) > 0 {
+		opts = append(opts, status.WithProbes(t.Probes...))
+	}
+	if t.FailureThreshold > 0 {
+		opts = append(opts, status.WithFailureThreshold(t.FailureThreshold))
+	}
+	if t.SuccessThreshold > 0 {
+		opts = append(opts, status.WithSuccessThreshold(t.SuccessThreshold))
+	}
+
+	return opts
+}
+
+// closeCheck closes the check when it holds resources.
+func closeCheck(c check.Check) {
+	if closer, ok := c.(io.Closer); ok {
+		closer.Close()
+	}
+}
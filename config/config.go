@@ -0,0 +1,227 @@
+// Package config builds a status.HealthChecker and status.Page from a declarative
+// YAML or JSON description of the targets, so that a dependency check can be added
+// without a code change:
+//
+//	title: Orders
+//	targets:
+//	  - name: Postgres
+//	    type: postgres
+//	    group: Databases
+//	    timeout: 3s
+//	    params:
+//	      dsn: postgres://orders@db:5432/orders
+//
+//	cfg, err := config.Load("status.yaml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	instance, err := cfg.Build(config.DefaultRegistry())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer instance.Close()
+//
+// Every target type maps to a constructor under check/... through a Registry.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalid is returned when a configuration fails validation.
+var ErrInvalid = errors.New("invalid configuration")
+
+// Config describes a HealthChecker and the status page built on it.
+type Config struct {
+	Title       string        `json:"title"        yaml:"title"`
+	ShowVersion bool          `json:"show_version" yaml:"show_version"`
+	Links       []Link        `json:"links"        yaml:"links"`
+	Checker     CheckerConfig `json:"checker"      yaml:"checker"`
+	Targets     []Target      `json:"targets"      yaml:"targets"`
+}
+
+// Link is a navigation link shown on the status page.
+type Link struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url"  yaml:"url"`
+}
+
+// CheckerConfig contains the settings of the HealthChecker itself.
+type CheckerConfig struct {
+	MaxConcurrency int      `json:"max_concurrency" yaml:"max_concurrency"`
+	ResultTTL      Duration `json:"result_ttl"      yaml:"result_ttl"`
+	CheckTimeout   Duration `json:"check_timeout"   yaml:"check_timeout"`
+	HistorySize    int      `json:"history_size"    yaml:"history_size"`
+}
+
+// Target describes a single health check target. Type selects the check
+// constructor in the Registry and Params are passed to it.
+type Target struct {
+	Name             string                  `json:"name"              yaml:"name"`
+	Type             string                  `json:"type"              yaml:"type"`
+	Params           map[string]any          `json:"params"            yaml:"params"`
+	Importance       status.TargetImportance `json:"importance"        yaml:"importance"`
+	Group            string                  `json:"group"             yaml:"group"`
+	Icon             string                  `json:"icon"              yaml:"icon"`
+	Timeout          Duration                `json:"timeout"           yaml:"timeout"`
+	Retries          int                     `json:"retries"           yaml:"retries"`
+	RetryDelay       Duration                `json:"retry_delay"       yaml:"retry_delay"`
+	Interval         Duration                `json:"interval"          yaml:"interval"`
+	Probes           []status.Probe          `json:"probes"            yaml:"probes"`
+	FailureThreshold int                     `json:"failure_threshold" yaml:"failure_threshold"`
+	SuccessThreshold int                     `json:"success_threshold" yaml:"success_threshold"`
+}
+
+// Duration is a time.Duration written as a string such as "5s" or "1m30s".
+type Duration time.Duration
+
+// UnmarshalYAML parses a duration string.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return fmt.Errorf("line %d: duration must be a string such as \"5s\": %w", value.Line, err)
+	}
+
+	return d.parse(s)
+}
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+
+	return d.parse(s)
+}
+
+func (d *Duration) parse(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// Load reads the configuration file at path. Files with the .json extension are
+// parsed as JSON and all others as YAML.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSON(data)
+	}
+
+	return ParseYAML(data)
+}
+
+// ParseYAML parses and validates a YAML configuration. Unknown fields are rejected.
+func ParseYAML(data []byte) (*Config, error) {
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// ParseJSON parses and validates a JSON configuration. Unknown fields are rejected.
+func ParseJSON(data []byte) (*Config, error) {
+	var config Config
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	decoder.UseNumber()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Validate checks the structure of the configuration: every target needs a unique
+// name, a type and a known importance, and durations must not be negative. The
+// types and params of targets are validated against a Registry by Build.
+func (c *Config) Validate() error {
+	var errs []error
+
+	names := make(map[string]bool, len(c.Targets))
+	for i, target := range c.Targets {
+		where := fmt.Sprintf("targets[%d]", i)
+		if target.Name != "" {
+			where = fmt.Sprintf("target %q", target.Name)
+		}
+
+		switch {
+		case target.Name == "":
+			errs = append(errs, fmt.Errorf("%s: name is required", where))
+		case names[target.Name]:
+			errs = append(errs, fmt.Errorf("%s: duplicate name", where))
+		}
+		names[target.Name] = true
+
+		if target.Type == "" {
+			errs = append(errs, fmt.Errorf("%s: type is required", where))
+		}
+
+		switch target.Importance {
+		case "", status.TargetImportanceHigh, status.TargetImportanceLow:
+		default:
+			errs = append(errs, fmt.Errorf("%s: unknown importance %q", where, target.Importance))
+		}
+
+		for _, probe := range target.Probes {
+			switch probe {
+			case status.ProbeLiveness, status.ProbeReadiness, status.ProbeStartup:
+			default:
+				errs = append(errs, fmt.Errorf("%s: unknown probe %q", where, probe))
+			}
+		}
+
+		if target.Timeout < 0 || target.RetryDelay < 0 || target.Interval < 0 {
+			errs = append(errs, fmt.Errorf("%s: durations must not be negative", where))
+		}
+		if target.Retries < 0 || target.FailureThreshold < 0 || target.SuccessThreshold < 0 {
+			errs = append(errs, fmt.Errorf("%s: retries and thresholds must not be negative", where))
+		}
+	}
+
+	if c.Checker.MaxConcurrency < 0 || c.Checker.HistorySize < 0 {
+		errs = append(errs, errors.New("checker: limits must not be negative"))
+	}
+	if c.Checker.ResultTTL < 0 || c.Checker.CheckTimeout < 0 {
+		errs = append(errs, errors.New("checker: durations must not be negative"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalid, errors.Join(errs...))
+	}
+
+	return nil
+}
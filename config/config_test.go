@@ -0,0 +1,247 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/check"
+)
+
+func TestLoad_BuildsCheckerAndPage(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	yaml := fmt.Sprintf(`
+title: Orders
+links:
+  - name: Docs
+    url: https://example.com/docs
+checker:
+  max_concurrency: 2
+targets:
+  - name: Database
+    type: tcp
+    group: Infra
+    timeout: 2s
+    params:
+      host: %s
+      port: %s
+  - name: API
+    type: http
+    importance: low
+    retries: 1
+    retry_delay: 10ms
+    params:
+      url: %s
+      expected_status: 204
+`, host, port, server.URL)
+
+	path := filepath.Join(t.TempDir(), "status.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	instance, err := config.Build(DefaultRegistry())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	defer instance.Close()
+
+	results, err := instance.Checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Target.Name != "Database" || results[0].Target.Group != "Infra" ||
+		results[0].Status != status.HealthTargetStatusOk {
+		t.Fatalf("unexpected database result: %+v", results[0])
+	}
+	if results[1].Target.Importance != status.TargetImportanceLow || results[1].Status != status.HealthTargetStatusOk {
+		t.Fatalf("unexpected api result: %+v", results[1])
+	}
+
+	w := httptest.NewRecorder()
+	instance.Page.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if body := w.Body.String(); !strings.Contains(body, "<title>Orders</title>") ||
+		!strings.Contains(body, "https://example.com/docs") {
+		t.Fatalf("unexpected page: %s", body)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseJSON([]byte(`{
+		"targets": [
+			{"name": "Cache", "type": "tcp", "interval": "15s", "params": {"host": "localhost", "port": 6379}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if time.Duration(config.Targets[0].Interval) != 15*time.Second {
+		t.Fatalf("unexpected interval %v", config.Targets[0].Interval)
+	}
+
+	instance, err := config.Build(DefaultRegistry())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	instance.Close()
+}
+
+func TestParseYAML_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		yaml     string
+		expected []string
+	}{
+		{
+			name:     "unknown field",
+			yaml:     "targets:\n  - name: a\n    type: tcp\n    importanc: low\n",
+			expected: []string{"field importanc not found"},
+		},
+		{
+			name:     "invalid duration",
+			yaml:     "targets:\n  - name: a\n    type: tcp\n    timeout: soon\n",
+			expected: []string{`invalid duration "soon"`},
+		},
+		{
+			name: "invalid targets",
+			yaml: "targets:\n" +
+				"  - type: tcp\n" +
+				"  - name: a\n    type: tcp\n    importance: critical\n" +
+				"  - name: a\n",
+			expected: []string{
+				"targets[0]: name is required",
+				`target "a": unknown importance "critical"`,
+				`target "a": duplicate name`,
+				`target "a": type is required`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseYAML([]byte(tt.yaml))
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("expected ErrInvalid, got %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Fatalf("expected error to contain %q, got %v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Build_ReportsInvalidTargets(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseYAML([]byte(`
+targets:
+  - name: Queue
+    type: amqp
+  - name: Database
+    type: tcp
+    params:
+      port: "5432"
+      hots: db
+  - name: Orders
+    type: remote
+    params:
+      url: http://orders:8080/health
+      nested: "yes"
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	_, err = config.Build(DefaultRegistry())
+	if !errors.Is(err, ErrInvalid) || !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("expected ErrInvalid and ErrUnknownType, got %v", err)
+	}
+
+	for _, expected := range []string{
+		`target "Queue": unknown target type "amqp"`,
+		`param "host" is required`,
+		`param "port" must be an integer, got 5432`,
+		`unknown param "hots"`,
+		`param "nested" must be a boolean, got yes`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected error to contain %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.Register("static", func(p *Params, _ check.Config) (check.Check, error) {
+		message := p.OptionalString("error", "")
+
+		return check.CheckFunc(func(ctx context.Context) error {
+			if message != "" {
+				return errors.New(message)
+			}
+
+			return nil
+		}), nil
+	})
+
+	config, err := ParseYAML([]byte("targets:\n  - name: Static\n    type: static\n    params:\n      error: down\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	instance, err := config.Build(registry)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	defer instance.Close()
+
+	results, _ := instance.Checker.Check(context.Background())
+	if results[0].Status != status.HealthTargetStatusFail || results[0].ErrorMessage != "down" {
+		t.Fatalf("unexpected result: %+v", results[0])
+	}
+	if types := registry.Types(); len(types) != 1 || types[0] != "static" {
+		t.Fatalf("unexpected types %v", types)
+	}
+}
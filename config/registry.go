@@ -0,0 +1,501 @@
+package config
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+	"github.com/alarmistdev/status/check/database/mysql"
+	"github.com/alarmistdev/status/check/database/postgres"
+	"github.com/alarmistdev/status/check/database/redis"
+	"github.com/alarmistdev/status/check/network/dns"
+	grpccheck "github.com/alarmistdev/status/check/network/grpc"
+	httpcheck "github.com/alarmistdev/status/check/network/http"
+	"github.com/alarmistdev/status/check/network/icmp"
+	"github.com/alarmistdev/status/check/network/latency"
+	"github.com/alarmistdev/status/check/network/tcp"
+	"github.com/alarmistdev/status/check/network/udp"
+	"github.com/alarmistdev/status/check/queue/kafka"
+	"github.com/alarmistdev/status/check/queue/nats"
+	"github.com/alarmistdev/status/check/queue/rabbitmq"
+	"github.com/alarmistdev/status/check/remote"
+	"github.com/alarmistdev/status/check/system"
+	"github.com/alarmistdev/status/check/system/docker"
+)
+
+const (
+	// defaultExpectedStatus is the HTTP status expected by http and graphql targets.
+	defaultExpectedStatus = 200
+	// defaultKafkaStaleAfter is the stale period of kafka-ping targets.
+	defaultKafkaStaleAfter = time.Minute
+	// filePermBase and filePermBits describe file permissions written as strings, such as "0644".
+	filePermBase = 8
+	filePermBits = 32
+)
+
+// ErrUnknownType is returned when a target type is not registered in the Registry.
+var ErrUnknownType = errors.New("unknown target type")
+
+// Factory builds the check of a target from its params. The config carries the
+// timeout and retry settings of the target. Factories read params through the
+// Params accessors, which collect missing, malformed and unknown params.
+type Factory func(params *Params, config check.Config) (check.Check, error)
+
+// Registry maps target types to check factories.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// DefaultRegistry creates a Registry with the checks of this module:
+//
+//   - http: method (default GET), url, expected_status (default 200)
+//   - graphql: method (default POST), url, expected_status (default 200)
+//   - tcp, udp: host, port
+//   - grpc: addr, service, tls (default false), server_name
+//   - latency: host, port, max_latency
+//   - dns, icmp: host
+//   - postgres, mysql: dsn
+//   - redis: addr, username, password
+//   - nats, rabbitmq: url
+//   - kafka-topics: brokers
+//   - kafka-ping: brokers, topic, stale_after (default 1m)
+//   - docker: labels, interval (refreshes in its own loop when set, otherwise on every run)
+//   - memory: max_usage_percent
+//   - disk: path, min_free_gb
+//   - file: path, perm (such as "0644")
+//   - process: name
+//   - remote: url of a status.HealthChecker.Handler, headers, nested (default false).
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	registerNetworkChecks(r)
+	registerDatabaseChecks(r)
+	registerQueueChecks(r)
+	registerSystemChecks(r)
+
+	return r
+}
+
+// registerNetworkChecks registers the network checks of DefaultRegistry.
+func registerNetworkChecks(r *Registry) {
+	r.Register("http", func(p *Params, config check.Config) (check.Check, error) {
+		return httpcheck.Check(p.OptionalString("method", "GET"), p.String("url"),
+			p.OptionalInt("expected_status", defaultExpectedStatus), config), nil
+	})
+	r.Register("graphql", func(p *Params, config check.Config) (check.Check, error) {
+		return httpcheck.CheckGraphQL(p.OptionalString("method", "POST"), p.String("url"),
+			p.OptionalInt("expected_status", defaultExpectedStatus), config), nil
+	})
+	r.Register("grpc", func(p *Params, config check.Config) (check.Check, error) {
+		var opts []grpccheck.Option
+		serverName := p.OptionalString("server_name", "")
+		if p.OptionalBool("tls", false) {
+			opts = append(opts, grpccheck.WithTLS(&tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}))
+		}
+
+		return grpccheck.Check(p.String("addr"), p.OptionalString("service", ""), config, opts...), nil
+	})
+	r.Register("tcp", func(p *Params, _ check.Config) (check.Check, error) {
+		return tcp.Check(p.String("host"), p.Int("port")), nil
+	})
+	r.Register("udp", func(p *Params, _ check.Config) (check.Check, error) {
+		return udp.Check(p.String("host"), p.Int("port")), nil
+	})
+	r.Register("latency", func(p *Params, _ check.Config) (check.Check, error) {
+		return latency.Check(p.String("host"), p.Int("port"), p.Duration("max_latency")), nil
+	})
+	r.Register("dns", func(p *Params, _ check.Config) (check.Check, error) {
+		return dns.Check(p.String("host")), nil
+	})
+	r.Register("icmp", func(p *Params, _ check.Config) (check.Check, error) {
+		return icmp.Check(p.String("host")), nil
+	})
+	r.Register("remote", func(p *Params, config check.Config) (check.Check, error) {
+		var opts []remote.Option
+		if _, ok := p.lookup("headers", false); ok {
+			for key, value := range p.StringMap("headers") {
+				opts = append(opts, remote.WithHeader(key, value))
+			}
+		}
+		if p.OptionalBool("nested", false) {
+			opts = append(opts, remote.WithNestedResults())
+		}
+
+		return remote.Check(p.String("url"), config, opts...), nil
+	})
+}
+
+// registerDatabaseChecks registers the database checks of DefaultRegistry.
+func registerDatabaseChecks(r *Registry) {
+	r.Register("postgres", func(p *Params, config check.Config) (check.Check, error) {
+		return postgres.Check(p.String("dsn"), config), nil
+	})
+	r.Register("mysql", func(p *Params, config check.Config) (check.Check, error) {
+		return mysql.Check(p.String("dsn"), config), nil
+	})
+	r.Register("redis", func(p *Params, config check.Config) (check.Check, error) {
+		addr := p.String("addr")
+		username := p.OptionalString("username", "")
+		password := p.OptionalString("password", "")
+		if username == "" && password == "" {
+			return redis.Check(addr, config), nil
+		}
+
+		return redis.CheckWithAuth(addr, username, password, config), nil
+	})
+}
+
+// registerQueueChecks registers the message queue checks of DefaultRegistry.
+func registerQueueChecks(r *Registry) {
+	r.Register("nats", func(p *Params, config check.Config) (check.Check, error) {
+		return nats.Check(p.String("url"), config), nil
+	})
+	r.Register("rabbitmq", func(p *Params, config check.Config) (check.Check, error) {
+		return rabbitmq.Check(p.String("url"), config), nil
+	})
+	r.Register("kafka-topics", func(p *Params, config check.Config) (check.Check, error) {
+		return kafka.TopicsCheck(p.Strings("brokers"), config), nil
+	})
+	r.Register("kafka-ping", func(p *Params, config check.Config) (check.Check, error) {
+		brokers, topic := p.Strings("brokers"), p.String("topic")
+		staleAfter := p.OptionalDuration("stale_after", defaultKafkaStaleAfter)
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+
+		c, err := kafka.PingCheck(brokers, topic, nil, staleAfter, config)
+		if err != nil {
+			return nil, fmt.Errorf("create kafka ping check: %w", err)
+		}
+
+		return c, nil
+	})
+}
+
+// registerSystemChecks registers the system checks of DefaultRegistry.
+func registerSystemChecks(r *Registry) {
+	r.Register("docker", func(p *Params, config check.Config) (check.Check, error) {
+		labels := p.StringMap("labels")
+		interval := p.OptionalDuration("interval", 0)
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+
+		var c check.Check
+		var err error
+		if interval > 0 {
+			c, err = docker.Check(labels, interval, config)
+		} else {
+			c, err = docker.OnDemandCheck(labels, config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("create docker check: %w", err)
+		}
+
+		return c, nil
+	})
+	r.Register("memory", func(p *Params, _ check.Config) (check.Check, error) {
+		return system.CheckMemory(p.Float("max_usage_percent")), nil
+	})
+	r.Register("disk", func(p *Params, _ check.Config) (check.Check, error) {
+		return system.CheckDiskSpace(p.String("path"), p.Float("min_free_gb")), nil
+	})
+	r.Register("file", func(p *Params, _ check.Config) (check.Check, error) {
+		return system.CheckFile(p.String("path"), p.FileMode("perm")), nil
+	})
+	r.Register("process", func(p *Params, _ check.Config) (check.Check, error) {
+		return system.CheckProcessStatus(p.String("name")), nil
+	})
+}
+
+// Register adds or replaces the factory of a target type.
+func (r *Registry) Register(typ string, factory Factory) {
+	r.factories[typ] = factory
+}
+
+// Types returns the registered target types in alphabetical order.
+func (r *Registry) Types() []string {
+	return slices.Sorted(maps.Keys(r.factories))
+}
+
+// build creates the check of a target. Missing, malformed and unknown params are
+// reported together.
+func (r *Registry) build(target Target, config check.Config) (check.Check, error) {
+	factory, ok := r.factories[target.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownType, target.Type)
+	}
+
+	params := newParams(target.Params)
+
+	c, err := factory(params, config)
+	if paramsErr := params.Err(); paramsErr != nil {
+		closeCheck(c)
+
+		return nil, paramsErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Params gives typed access to the params of a target. Accessors record an error
+// when a required param is missing or has the wrong type and return the zero value,
+// so that a Factory can read all params and report every problem at once through Err.
+type Params struct {
+	values map[string]any
+	used   map[string]bool
+	errs   []error
+}
+
+func newParams(values map[string]any) *Params {
+	return &Params{values: values, used: make(map[string]bool, len(values))}
+}
+
+// Err returns the errors recorded by the accessors, including params that were
+// set in the configuration but never read by the factory.
+func (p *Params) Err() error {
+	errs := slices.Clone(p.errs)
+
+	unknown := make([]string, 0)
+	for key := range p.values {
+		if !p.used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("unknown param %q", key))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns the required string param key.
+func (p *Params) String(key string) string {
+	value, ok := p.lookup(key, true)
+	if !ok {
+		return ""
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		p.invalid(key, "a string", value)
+	}
+
+	return s
+}
+
+// OptionalString returns the string param key, or def when it is not set.
+func (p *Params) OptionalString(key, def string) string {
+	if _, ok := p.lookup(key, false); !ok {
+		return def
+	}
+
+	return p.String(key)
+}
+
+// Bool returns the required boolean param key.
+func (p *Params) Bool(key string) bool {
+	value, ok := p.lookup(key, true)
+	if !ok {
+		return false
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		p.invalid(key, "a boolean", value)
+	}
+
+	return b
+}
+
+// OptionalBool returns the boolean param key, or def when it is not set.
+func (p *Params) OptionalBool(key string, def bool) bool {
+	if _, ok := p.lookup(key, false); !ok {
+		return def
+	}
+
+	return p.Bool(key)
+}
+
+// Int returns the required integer param key.
+func (p *Params) Int(key string) int {
+	value, ok := p.lookup(key, true)
+	if !ok {
+		return 0
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v
+	case json.Number:
+		if n, err := strconv.Atoi(v.String()); err == nil {
+			return n
+		}
+	}
+	p.invalid(key, "an integer", value)
+
+	return 0
+}
+
+// OptionalInt returns the integer param key, or def when it is not set.
+func (p *Params) OptionalInt(key string, def int) int {
+	if _, ok := p.lookup(key, false); !ok {
+		return def
+	}
+
+	return p.Int(key)
+}
+
+// Float returns the required number param key.
+func (p *Params) Float(key string) float64 {
+	value, ok := p.lookup(key, true)
+	if !ok {
+		return 0
+	}
+
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	p.invalid(key, "a number", value)
+
+	return 0
+}
+
+// Duration returns the required duration param key, written as a string such as "5s".
+func (p *Params) Duration(key string) time.Duration {
+	s := p.String(key)
+	if s == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("param %q: invalid duration %q", key, s))
+	}
+
+	return d
+}
+
+// OptionalDuration returns the duration param key, or def when it is not set.
+func (p *Params) OptionalDuration(key string, def time.Duration) time.Duration {
+	if _, ok := p.lookup(key, false); !ok {
+		return def
+	}
+
+	return p.Duration(key)
+}
+
+// Strings returns the required list of strings param key.
+func (p *Params) Strings(key string) []string {
+	value, ok := p.lookup(key, true)
+	if !ok {
+		return nil
+	}
+
+	list, ok := value.([]any)
+	if !ok {
+		p.invalid(key, "a list of strings", value)
+
+		return nil
+	}
+
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			p.invalid(key, "a list of strings", value)
+
+			return nil
+		}
+		strs = append(strs, s)
+	}
+
+	return strs
+}
+
+// StringMap returns the required string to string mapping param key.
+func (p *Params) StringMap(key string) map[string]string {
+	value, ok := p.lookup(key, true)
+	if !ok {
+		return nil
+	}
+
+	mapping, ok := value.(map[string]any)
+	if !ok {
+		p.invalid(key, "a mapping of strings", value)
+
+		return nil
+	}
+
+	strs := make(map[string]string, len(mapping))
+	for k, item := range mapping {
+		s, ok := item.(string)
+		if !ok {
+			p.invalid(key, "a mapping of strings", value)
+
+			return nil
+		}
+		strs[k] = s
+	}
+
+	return strs
+}
+
+// FileMode returns the required file permission param key, written as an octal string such as "0644".
+func (p *Params) FileMode(key string) fs.FileMode {
+	s := p.String(key)
+	if s == "" {
+		return 0
+	}
+
+	mode, err := strconv.ParseUint(s, filePermBase, filePermBits)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("param %q: invalid file mode %q", key, s))
+	}
+
+	return fs.FileMode(mode)
+}
+
+// lookup returns the param key and marks it as used. A missing required param is recorded as error.
+func (p *Params) lookup(key string, required bool) (any, bool) {
+	p.used[key] = true
+
+	value, ok := p.values[key]
+	if !ok || value == nil {
+		if required {
+			p.errs = append(p.errs, fmt.Errorf("param %q is required", key))
+		}
+
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (p *Params) invalid(key, want string, value any) {
+	p.errs = append(p.errs, fmt.Errorf("param %q must be %s, got %v", key, want, value))
+}
@@ -0,0 +1,89 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_FlapDamping(t *testing.T) {
+	t.Parallel()
+
+	outcomes := []error{
+		nil,
+		errors.New("timeout 1"),
+		errors.New("timeout 2"),
+		errors.New("timeout 3"),
+		nil,
+		errors.New("timeout 4"),
+		nil,
+		nil,
+	}
+	expected := []HealthTargetStatus{
+		HealthTargetStatusOk,
+		HealthTargetStatusOk,
+		HealthTargetStatusOk,
+		HealthTargetStatusFail,
+		HealthTargetStatusFail,
+		HealthTargetStatusFail,
+		HealthTargetStatusFail,
+		HealthTargetStatusOk,
+	}
+
+	run := 0
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			err := outcomes[run]
+			run++
+
+			return err
+		}), WithFailureThreshold(3), WithSuccessThreshold(2))
+
+	for i, want := range expected {
+		results, err := checker.Check(context.Background())
+		if err != nil {
+			t.Fatalf("check: %v", err)
+		}
+		if results[0].Status != want {
+			t.Fatalf("run %d: expected status %s, got %s", i, want, results[0].Status)
+		}
+		if want == HealthTargetStatusFail && results[0].ErrorMessage == "" {
+			t.Fatalf("run %d: expected failed result to carry the last error", i)
+		}
+		if want == HealthTargetStatusOk && (results[0].ErrorMessage != "" || results[0].err != nil) {
+			t.Fatalf("run %d: expected ok result without an error, got %q", i, results[0].ErrorMessage)
+		}
+	}
+}
+
+func TestHealthChecker_Handler_DampedFailureKeepsOk(t *testing.T) {
+	t.Parallel()
+
+	run := 0
+	checker := NewHealthChecker().
+		WithTarget("redis", check.CheckFunc(func(ctx context.Context) error {
+			run++
+			if run == 2 {
+				return errors.New("transient timeout")
+			}
+
+			return nil
+		}), WithFailureThreshold(2))
+
+	for range 2 {
+		recorder := executeHandlerRequest(t, checker, "")
+		assertStatusCode(t, http.StatusOK, recorder.Code)
+		if body := recorder.Body.String(); strings.Contains(body, "transient timeout") {
+			t.Fatalf("expected the damped failure to be reported without its error, got %s", body)
+		}
+	}
+
+	history, err := checker.History("redis")
+	if err != nil || len(history) != 2 || history[1].Error != "transient timeout" {
+		t.Fatalf("expected the damped failure in the history, got %+v, %v", history, err)
+	}
+}
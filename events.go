@@ -0,0 +1,176 @@
+package status
+
+import (
+	"context"
+	"time"
+)
+
+// StatusChangeEvent describes a change of the reported status of a target.
+// Previous is empty for the first run of a target.
+type StatusChangeEvent struct {
+	Target       HealthTarget
+	Previous     HealthTargetStatus
+	Current      HealthTargetStatus
+	Err          error
+	ErrorMessage string
+	Duration     time.Duration
+	// Conclusion is the overall conclusion after the change.
+	Conclusion Conclusion
+	Time       time.Time
+}
+
+// ConclusionChangeEvent describes a change of the overall conclusion of the HealthChecker.
+// Previous is empty for the first conclusion.
+type ConclusionChangeEvent struct {
+	Previous Conclusion
+	Current  Conclusion
+	Time     time.Time
+}
+
+// OnStatusChange registers a hook called whenever the reported status of a target
+// changes, after flap damping is applied. The first run of a target is reported
+// as a change only when the target is not healthy. Changes of targets in
+// maintenance are held back and reported once the window ends (see MaintenanceWindow).
+//
+// Hooks are called synchronously and one at a time, so they must neither block
+// nor call back into the HealthChecker; hand slow work such as network calls off
+// to another goroutine.
+func (c *HealthChecker) OnStatusChange(hook func(context.Context, StatusChangeEvent)) *HealthChecker {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+
+	c.statusHooks = append(c.statusHooks, hook)
+
+	return c
+}
+
+// OnConclusionChange registers a hook called whenever the overall conclusion of
+// the HealthChecker changes. The first conclusion is reported as a change only
+// when it is not ConclusionOk. See OnStatusChange for how hooks are called.
+func (c *HealthChecker) OnConclusionChange(hook func(context.Context, ConclusionChangeEvent)) *HealthChecker {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+
+	c.conclusionHooks = append(c.conclusionHooks, hook)
+
+	return c
+}
+
+// notify calls the registered hooks when the reported result of the target at
+// index differs from the status last sent to them or changes the overall conclusion.
+func (c *HealthChecker) notify(ctx context.Context, index int, result HealthCheckResult) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+
+	if len(c.statusHooks) == 0 && len(c.conclusionHooks) == 0 {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+	conclusion := calculateConclusion(c.applyOverrides(c.applyMaintenance(c.reportedResults(), now)))
+
+	c.notifyStatus(ctx, index, result, conclusion, now)
+	c.notifyConclusion(ctx, conclusion, now)
+}
+
+// notifyPending reports the changes of all targets that were held back while
+// they were in maintenance, without waiting for their next check run.
+func (c *HealthChecker) notifyPending(ctx context.Context) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+
+	if len(c.statusHooks) == 0 && len(c.conclusionHooks) == 0 {
+		return
+	}
+
+	now := time.Now()
+	conclusion := calculateConclusion(c.applyOverrides(c.applyMaintenance(c.reportedResults(), now)))
+
+	for i, state := range c.states {
+		if result, ok := state.lastResult(); ok {
+			c.notifyStatus(ctx, i, result, conclusion, now)
+		}
+	}
+	c.notifyConclusion(ctx, conclusion, now)
+}
+
+// notifyStatus calls the status hooks when the status of the target at index
+// differs from the status last sent to them. Targets in maintenance are skipped
+// and keep their last sent status, so that a change made during the window is
+// reported once it ends. The caller must hold hooksMu.
+func (c *HealthChecker) notifyStatus(
+	ctx context.Context,
+	index int,
+	result HealthCheckResult,
+	conclusion Conclusion,
+	now time.Time,
+) {
+	if _, silenced := c.activeMaintenance(result.Target, now); silenced {
+		return
+	}
+
+	state := c.states[index]
+	previous := state.notified
+	state.notified = result.Status
+
+	if !isChange(string(previous), string(result.Status), string(HealthTargetStatusOk)) {
+		return
+	}
+
+	event := StatusChangeEvent{
+		Target:       result.Target,
+		Previous:     previous,
+		Current:      result.Status,
+		Err:          result.err,
+		ErrorMessage: result.ErrorMessage,
+		Duration:     result.Duration,
+		Conclusion:   conclusion,
+		Time:         now,
+	}
+	for _, hook := range c.statusHooks {
+		hook(ctx, event)
+	}
+}
+
+// notifyConclusion calls the conclusion hooks when the conclusion changed.
+// The caller must hold hooksMu.
+func (c *HealthChecker) notifyConclusion(ctx context.Context, conclusion Conclusion, now time.Time) {
+	previousConclusion := c.conclusion
+	c.conclusion = conclusion
+
+	if !isChange(string(previousConclusion), string(conclusion), string(ConclusionOk)) {
+		return
+	}
+
+	event := ConclusionChangeEvent{
+		Previous: previousConclusion,
+		Current:  conclusion,
+		Time:     now,
+	}
+	for _, hook := range c.conclusionHooks {
+		hook(ctx, event)
+	}
+}
+
+// reportedResults returns the latest reported results of all targets checked so far.
+func (c *HealthChecker) reportedResults() []HealthCheckResult {
+	results := make([]HealthCheckResult, 0, len(c.states))
+	for _, state := range c.states {
+		if result, ok := state.lastResult(); ok {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// isChange reports whether moving from previous to current is a change worth
+// reporting. The initial value is only reported when it differs from healthy.
+func isChange(previous, current, healthy string) bool {
+	if previous == "" {
+		return current != healthy
+	}
+
+	return previous != current
+}
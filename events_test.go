@@ -0,0 +1,86 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_OnStatusChange(t *testing.T) {
+	t.Parallel()
+
+	outcomes := []error{nil, nil, errors.New("refused"), errors.New("refused"), nil}
+	run := 0
+
+	var statusEvents []StatusChangeEvent
+	var conclusionEvents []ConclusionChangeEvent
+
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			err := outcomes[run]
+			run++
+
+			return err
+		})).
+		OnStatusChange(func(_ context.Context, event StatusChangeEvent) {
+			statusEvents = append(statusEvents, event)
+		}).
+		OnConclusionChange(func(_ context.Context, event ConclusionChangeEvent) {
+			conclusionEvents = append(conclusionEvents, event)
+		})
+
+	for range outcomes {
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("check: %v", err)
+		}
+	}
+
+	if len(statusEvents) != 2 {
+		t.Fatalf("expected 2 status events, got %d: %+v", len(statusEvents), statusEvents)
+	}
+
+	failed := statusEvents[0]
+	if failed.Target.Name != "postgres" || failed.Previous != HealthTargetStatusOk ||
+		failed.Current != HealthTargetStatusFail || failed.ErrorMessage != "refused" ||
+		failed.Conclusion != ConclusionFail || failed.Time.IsZero() {
+		t.Fatalf("unexpected failure event: %+v", failed)
+	}
+
+	recovered := statusEvents[1]
+	if recovered.Previous != HealthTargetStatusFail || recovered.Current != HealthTargetStatusOk ||
+		recovered.Conclusion != ConclusionOk {
+		t.Fatalf("unexpected recovery event: %+v", recovered)
+	}
+
+	if len(conclusionEvents) != 2 ||
+		conclusionEvents[0].Previous != ConclusionOk || conclusionEvents[0].Current != ConclusionFail ||
+		conclusionEvents[1].Previous != ConclusionFail || conclusionEvents[1].Current != ConclusionOk {
+		t.Fatalf("unexpected conclusion events: %+v", conclusionEvents)
+	}
+}
+
+func TestHealthChecker_OnStatusChange_InitialFailure(t *testing.T) {
+	t.Parallel()
+
+	var events []StatusChangeEvent
+	checker := NewHealthChecker().
+		WithTarget("healthy", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		})).
+		WithTarget("broken", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("refused")
+		}), WithImportance(TargetImportanceLow)).
+		OnStatusChange(func(_ context.Context, event StatusChangeEvent) {
+			events = append(events, event)
+		})
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if len(events) != 1 || events[0].Target.Name != "broken" || events[0].Previous != "" {
+		t.Fatalf("expected a single initial failure event, got %+v", events)
+	}
+}
@@ -64,6 +64,7 @@ func main() {
 
 	http.HandleFunc("/health", healthChecker.Handler())
 	http.HandleFunc("/status", statusPage.Handler())
+	http.HandleFunc("/metrics", healthChecker.MetricsHandler())
 
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
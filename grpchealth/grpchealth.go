@@ -0,0 +1,193 @@
+// Package grpchealth implements the gRPC health checking protocol
+// (grpc.health.v1.Health) backed by a status.HealthChecker, for gRPC-only
+// backends and Kubernetes gRPC probes:
+//
+//	server := grpc.NewServer()
+//	grpc_health_v1.RegisterHealthServer(server, grpchealth.NewServer(healthChecker,
+//		grpchealth.WithGroupService("orders.v1.Orders", "Databases"),
+//	))
+//
+// The empty service name reports the application as a whole.
+package grpchealth
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// defaultWatchInterval is how often Watch re-evaluates the status of a service.
+const defaultWatchInterval = 5 * time.Second
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithTargetService maps a service name to the given targets.
+func WithTargetService(service string, targets ...string) Option {
+	return func(s *Server) {
+		s.services[service] = func(target status.HealthTarget) bool {
+			return slices.Contains(targets, target.Name)
+		}
+	}
+}
+
+// WithGroupService maps a service name to the targets of the given group (see status.WithGroup).
+func WithGroupService(service, group string) Option {
+	return func(s *Server) {
+		s.services[service] = func(target status.HealthTarget) bool {
+			return target.Group == group
+		}
+	}
+}
+
+// WithWatchInterval sets how often Watch re-evaluates the status of a service.
+// The default is 5 seconds.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(s *Server) {
+		s.watchInterval = interval
+	}
+}
+
+// Server implements grpc_health_v1.HealthServer backed by a status.HealthChecker.
+//
+// A service mapped with WithTargetService or WithGroupService is SERVING unless one
+// of its targets fails, whatever its importance. Unless mapped explicitly, the empty
+// service name is SERVING unless a high importance target fails, like the status code
+// of HealthChecker.Handler. Every service is NOT_SERVING while the HealthChecker is draining.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	checker       *status.HealthChecker
+	services      map[string]func(status.HealthTarget) bool
+	watchInterval time.Duration
+}
+
+// NewServer creates a Server reporting the results of checker.
+func NewServer(checker *status.HealthChecker, opts ...Option) *Server {
+	s := &Server{
+		checker:       checker,
+		services:      make(map[string]func(status.HealthTarget) bool),
+		watchInterval: defaultWatchInterval,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Check implements grpc_health_v1.HealthServer. Unknown services get the NotFound code.
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus, err := s.servingStatus(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	if servingStatus == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, grpcstatus.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// List implements grpc_health_v1.HealthServer with the status of every service.
+func (s *Server) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	results, err := s.checker.Check(ctx)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Unavailable, "health check: %v", err)
+	}
+
+	statuses := map[string]*healthpb.HealthCheckResponse{
+		"": {Status: s.evaluate("", results)},
+	}
+	for service := range maps.Keys(s.services) {
+		statuses[service] = &healthpb.HealthCheckResponse{Status: s.evaluate(service, results)}
+	}
+
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch implements grpc_health_v1.HealthServer. The status of the service is
+// re-evaluated on the watch interval and sent whenever it changes. Unknown
+// services are reported as SERVICE_UNKNOWN.
+func (s *Server) Watch(
+	req *healthpb.HealthCheckRequest,
+	stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse],
+) error {
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		servingStatus, err := s.servingStatus(ctx, req.GetService())
+		if err != nil {
+			return err
+		}
+
+		if servingStatus != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return fmt.Errorf("send health status: %w", err)
+			}
+			last = servingStatus
+		}
+
+		select {
+		case <-ctx.Done():
+			return grpcstatus.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// servingStatus checks the targets and returns the status of the service.
+func (s *Server) servingStatus(
+	ctx context.Context,
+	service string,
+) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	if _, ok := s.services[service]; !ok && service != "" {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, nil
+	}
+
+	results, err := s.checker.Check(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, grpcstatus.FromContextError(ctx.Err()).Err()
+		}
+
+		return 0, grpcstatus.Errorf(codes.Unavailable, "health check: %v", err)
+	}
+
+	return s.evaluate(service, results), nil
+}
+
+// evaluate returns the status of a known service from the results.
+func (s *Server) evaluate(
+	service string,
+	results []status.HealthCheckResult,
+) healthpb.HealthCheckResponse_ServingStatus {
+	if s.checker.Draining() {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	selects, mapped := s.services[service]
+	for _, result := range results {
+		if result.Status != status.HealthTargetStatusFail {
+			continue
+		}
+		if mapped && selects(result.Target) || !mapped && result.Target.Importance == status.TargetImportanceHigh {
+			return healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,148 @@
+package grpchealth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/check"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestServer_Check(t *testing.T) {
+	t.Parallel()
+
+	checker := status.NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}), status.WithGroup("Databases")).
+		WithTarget("cache", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}), status.WithImportance(status.TargetImportanceLow))
+
+	client := startServer(t, NewServer(checker,
+		WithGroupService("orders.Databases", "Databases"),
+		WithTargetService("orders.Cache", "cache"),
+	))
+
+	tests := []struct {
+		service  string
+		expected healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"", healthpb.HealthCheckResponse_SERVING},
+		{"orders.Databases", healthpb.HealthCheckResponse_SERVING},
+		{"orders.Cache", healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, tt := range tests {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+		if err != nil {
+			t.Fatalf("check %q: %v", tt.service, err)
+		}
+		if resp.GetStatus() != tt.expected {
+			t.Fatalf("service %q: expected %s, got %s", tt.service, tt.expected, resp.GetStatus())
+		}
+	}
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	if grpcstatus.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown service, got %v", err)
+	}
+
+	list, err := client.List(context.Background(), &healthpb.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list.GetStatuses()) != 3 ||
+		list.GetStatuses()["orders.Cache"].GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("unexpected list: %v", list.GetStatuses())
+	}
+
+	checker.Drain()
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING while draining, got %v %v", resp.GetStatus(), err)
+	}
+}
+
+func TestServer_Watch(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	checker := status.NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			if failing.Load() {
+				return errors.New("connection refused")
+			}
+
+			return nil
+		}))
+
+	client := startServer(t, NewServer(checker, WithWatchInterval(10*time.Millisecond)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	expectStatus := func(expected healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("receive: %v", err)
+		}
+		if resp.GetStatus() != expected {
+			t.Fatalf("expected %s, got %s", expected, resp.GetStatus())
+		}
+	}
+
+	expectStatus(healthpb.HealthCheckResponse_SERVING)
+	failing.Store(true)
+	expectStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	unknown, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if resp, err := unknown.Recv(); err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		t.Fatalf("expected SERVICE_UNKNOWN, got %v %v", resp.GetStatus(), err)
+	}
+}
+
+// startServer serves the health server on a local port and returns a client.
+func startServer(t *testing.T, server *Server) healthpb.HealthClient {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return healthpb.NewHealthClient(conn)
+}
@@ -9,9 +9,13 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alarmistdev/status/check"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -22,6 +26,12 @@ type HealthTarget struct {
 	Icon       string           `json:"icon,omitempty"`
 	Group      string           `json:"group,omitempty"`
 	check      check.Check
+	interval   time.Duration
+	timeout    time.Duration
+	probes     []Probe
+
+	failureThreshold int
+	successThreshold int
 }
 
 // TargetImportance defines the importance level of a health check target.
@@ -37,12 +47,79 @@ const (
 // HealthChecker manages a collection of health check targets and provides
 // functionality to check their health status.
 type HealthChecker struct {
-	targets []HealthTarget
+	targets      []HealthTarget
+	states       []*targetState
+	histories    []*history
+	historySize  int
+	store        HistoryStore
+	tracer       trace.Tracer
+	checkTimeout time.Duration
+	authorize    Authorizer
+
+	hooksMu         sync.Mutex
+	statusHooks     []func(context.Context, StatusChangeEvent)
+	conclusionHooks []func(context.Context, ConclusionChangeEvent)
+	conclusion      Conclusion
+
+	mu      sync.RWMutex
+	running bool
+	latest  []HealthCheckResult
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	maxConcurrency   int
+	groupConcurrency map[string]int
+	limit            semaphore
+	groupLimits      map[string]semaphore
+
+	maintenanceMu  sync.RWMutex
+	maintenance    []MaintenanceWindow
+	maintenanceSeq int
+
+	overridesMu sync.RWMutex
+	overrides   map[string]HealthTargetStatus
+
+	flightMu  sync.Mutex
+	flights   map[Probe]*inflight
+	resultTTL time.Duration
+	ttlCache  map[Probe]ttlEntry
+
+	draining      atomic.Bool
+	startupPassed atomic.Bool
+}
+
+// CheckerOption is a function that configures a HealthChecker.
+type CheckerOption func(*HealthChecker)
+
+// WithHistorySize sets the number of check runs kept per target for History and Stats.
+func WithHistorySize(size int) CheckerOption {
+	return func(c *HealthChecker) {
+		c.historySize = size
+	}
+}
+
+// WithHistoryStore persists every check run in the given store, so that
+// history and statistics survive restarts of the application.
+func WithHistoryStore(store HistoryStore) CheckerOption {
+	return func(c *HealthChecker) {
+		c.store = store
+	}
 }
 
 // NewHealthChecker creates a new HealthChecker instance.
-func NewHealthChecker() *HealthChecker {
-	return &HealthChecker{}
+func NewHealthChecker(opts ...CheckerOption) *HealthChecker {
+	c := &HealthChecker{
+		historySize: defaultHistorySize,
+		tracer:      noop.NewTracerProvider().Tracer(tracerName),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.initLimits()
+
+	return c
 }
 
 // TargetOption is a function that configures a HealthTarget.
@@ -70,12 +147,40 @@ func WithGroup(group string) TargetOption {
 	}
 }
 
+// WithInterval sets how often the target is checked when the HealthChecker
+// runs in background mode. See HealthChecker.Start.
+func WithInterval(interval time.Duration) TargetOption {
+	return func(t *HealthTarget) {
+		t.interval = interval
+	}
+}
+
+// WithFailureThreshold sets the number of consecutive failed runs after which
+// a healthy target is reported as failed. Defaults to 1.
+func WithFailureThreshold(n int) TargetOption {
+	return func(t *HealthTarget) {
+		t.failureThreshold = n
+	}
+}
+
+// WithSuccessThreshold sets the number of consecutive passing runs after which
+// a failed target is reported as healthy again. Defaults to 1.
+func WithSuccessThreshold(n int) TargetOption {
+	return func(t *HealthTarget) {
+		t.successThreshold = n
+	}
+}
+
 // WithTarget adds a new health check target to the checker.
 func (c *HealthChecker) WithTarget(name string, check check.Check, opts ...TargetOption) *HealthChecker {
 	target := HealthTarget{
 		Name:       name,
 		Importance: TargetImportanceHigh,
 		check:      check,
+		probes:     []Probe{ProbeReadiness, ProbeStartup},
+
+		failureThreshold: 1,
+		successThreshold: 1,
 	}
 
 	for _, opt := range opts {
@@ -83,40 +188,47 @@ func (c *HealthChecker) WithTarget(name string, check check.Check, opts ...Targe
 	}
 
 	c.targets = append(c.targets, target)
+	c.states = append(c.states, &targetState{})
+	c.histories = append(c.histories, newHistory(c.historySize))
 
 	return c
 }
 
 func (c *HealthChecker) Handler() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.Draining() {
+			respondDraining(w)
+
+			return
+		}
+
 		if _, noDeps := r.URL.Query()["no_deps"]; noDeps {
 			w.WriteHeader(http.StatusOK)
 
 			return
 		}
 
-		ctx := r.Context()
-
-		results, err := c.Check(ctx)
+		results, err := c.Check(r.Context())
 		if err != nil {
 			respondJSON(w, http.StatusInternalServerError, err)
 
 			return
 		}
 
-		status := http.StatusOK
-
-		for _, result := range results {
-			if result.Target.Importance == TargetImportanceHigh &&
-				(result.Status != HealthTargetStatusOk || result.err != nil) {
-				status = http.StatusInternalServerError
+		respondJSON(w, resultsStatusCode(results), c.visibleResults(r, results))
+	})
+}
 
-				break
-			}
+// resultsStatusCode returns the HTTP status code for the given results:
+// 500 when a high importance target failed and 200 otherwise.
+func resultsStatusCode(results []HealthCheckResult) int {
+	for _, result := range results {
+		if result.Target.Importance == TargetImportanceHigh && result.Status == HealthTargetStatusFail {
+			return http.StatusInternalServerError
 		}
+	}
 
-		respondJSON(w, status, results)
-	})
+	return http.StatusOK
 }
 
 // HealthTargetStatus represents the status of a health check target.
@@ -127,6 +239,12 @@ const (
 	HealthTargetStatusOk = HealthTargetStatus("ok")
 	// HealthTargetStatusFail indicates that the target is unhealthy.
 	HealthTargetStatusFail = HealthTargetStatus("fail")
+	// HealthTargetStatusDegraded indicates that the target works but is degraded.
+	// See check.Degraded.
+	HealthTargetStatusDegraded = HealthTargetStatus("degraded")
+	// HealthTargetStatusMaintenance indicates that the target is in a maintenance
+	// window and its outcome is ignored. See MaintenanceWindow.
+	HealthTargetStatusMaintenance = HealthTargetStatus("maintenance")
 )
 
 // HealthCheckResult contains the result of a health check for a target.
@@ -135,38 +253,74 @@ type HealthCheckResult struct {
 	Status       HealthTargetStatus `json:"status"`
 	ErrorMessage string             `json:"error,omitempty"`
 	Duration     time.Duration      `json:"duration,omitempty"`
-	err          error
+	CheckedAt    time.Time          `json:"checked_at"`
+	// Details, ObservedValue, ObservedUnit and Measurement are reported by
+	// checks implementing check.ResultCheck. See check.Result.
+	Details       map[string]any `json:"details,omitempty"`
+	ObservedValue any            `json:"observed_value,omitempty"`
+	ObservedUnit  string         `json:"observed_unit,omitempty"`
+	Measurement   string         `json:"measurement,omitempty"`
+	// Maintenance is the active maintenance window of a target in maintenance.
+	Maintenance *MaintenanceWindow `json:"maintenance,omitempty"`
+	// Overridden is true when the status was forced with ForceStatus.
+	Overridden bool `json:"overridden,omitempty"`
+	// Stack is the stack trace of a check that panicked.
+	Stack string `json:"stack,omitempty"`
+	err   error
 }
 
 // Check performs health checks for all registered targets concurrently.
+// Concurrent calls share a single run of the checks (see also WithResultTTL).
+// When the HealthChecker runs in background mode, the latest cached results
+// are returned instead and no checks are executed.
 func (c *HealthChecker) Check(ctx context.Context) ([]HealthCheckResult, error) {
-	results := make([]HealthCheckResult, len(c.targets))
+	return c.check(ctx, "")
+}
+
+// check returns the results of the targets participating in the given probe,
+// or of all targets when probe is empty. Maintenance windows and overrides are applied.
+func (c *HealthChecker) check(ctx context.Context, probe Probe) ([]HealthCheckResult, error) {
+	results, ok := c.cachedResults()
+	if ok {
+		results = filterProbe(results, probe)
+	} else {
+		var err error
+		if results, err = c.coalescedCheck(ctx, probe); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.applyOverrides(c.applyMaintenance(results, time.Now())), nil
+}
+
+// checkTargets concurrently runs the checks of the targets participating in
+// the given probe, or of all targets when probe is empty. The results are in
+// registration order regardless of concurrency limits.
+func (c *HealthChecker) checkTargets(ctx context.Context, probe Probe) ([]HealthCheckResult, error) {
+	indexes := make([]int, 0, len(c.targets))
+	for i, target := range c.targets {
+		if target.participates(probe) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	results := make([]HealthCheckResult, len(indexes))
+
+	ctx, cancel := withTimeout(ctx, c.checkTimeout, "overall check deadline")
+	defer cancel()
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	for i := range c.targets {
-		index := i
-		target := c.targets[i]
+	for i, index := range indexes {
+		target := c.targets[index]
 		g.Go(func() error {
-			start := time.Now()
-			err := target.check.Check(ctx)
-			duration := time.Since(start)
-
-			if err != nil {
-				results[index] = HealthCheckResult{
-					Target:       target,
-					Status:       HealthTargetStatusFail,
-					err:          err,
-					ErrorMessage: err.Error(),
-					Duration:     duration,
-				}
-			} else {
-				results[index] = HealthCheckResult{
-					Target:   target,
-					Status:   HealthTargetStatusOk,
-					Duration: duration,
-				}
+			result := c.runTarget(ctx, target)
+			if callerAborted(ctx) {
+				results[i] = result
+
+				return nil
 			}
+			results[i] = c.record(ctx, index, result)
 
 			return nil
 		})
@@ -179,10 +333,72 @@ func (c *HealthChecker) Check(ctx context.Context) ([]HealthCheckResult, error)
 	return results, nil
 }
 
+// record stores the raw result of a target run in its history and returns
+// the result to report after flap damping.
+func (c *HealthChecker) record(ctx context.Context, index int, result HealthCheckResult) HealthCheckResult {
+	entry := newHistoryEntry(result)
+	c.histories[index].add(entry)
+
+	if c.store != nil {
+		if err := c.store.Append(context.WithoutCancel(ctx), result.Target.Name, entry); err != nil {
+			log.Printf("appending history of target %s: %v", result.Target.Name, err)
+		}
+	}
+
+	reported := c.states[index].apply(result)
+	c.notify(ctx, index, reported)
+
+	return reported
+}
+
+// executeTarget executes the check of a single target within its timeout and
+// converts the outcome into a HealthCheckResult.
+func executeTarget(ctx context.Context, target HealthTarget) HealthCheckResult {
+	ctx, cancel := withTimeout(ctx, target.timeout, "target timeout")
+	defer cancel()
+
+	start := time.Now()
+	outcome := runCheck(ctx, target)
+	duration := time.Since(start)
+
+	result := HealthCheckResult{
+		Target:        target,
+		Status:        HealthTargetStatusOk,
+		Duration:      duration,
+		CheckedAt:     start,
+		Details:       outcome.result.Details,
+		ObservedValue: outcome.result.ObservedValue,
+		ObservedUnit:  outcome.result.Unit,
+		Measurement:   outcome.result.Measurement,
+		Stack:         outcome.stack,
+	}
+
+	if err := outcome.err; err != nil {
+		result.Status = HealthTargetStatusFail
+		if check.IsDegraded(err) {
+			result.Status = HealthTargetStatusDegraded
+		}
+		result.err = err
+		result.ErrorMessage = err.Error()
+	}
+
+	return result
+}
+
+// respondDraining responds with 503 to tell load balancers to stop sending traffic.
+func respondDraining(w http.ResponseWriter) {
+	http.Error(w, "draining", http.StatusServiceUnavailable)
+}
+
 // respondJSON responds JSON body with a given code. It sets
 // Content-Type header.
 func respondJSON(w http.ResponseWriter, code int, data any) {
-	w.Header().Set("Content-Type", "application/json")
+	respond(w, "application/json", code, data)
+}
+
+// respond responds JSON body with a given content type and code.
+func respond(w http.ResponseWriter, contentType string, code int, data any) {
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(&data); err != nil {
 		log.Printf("encoding data to respond with json: %v", err)
@@ -174,6 +174,65 @@ func TestHealthChecker_Handler_HighImportanceFailure(t *testing.T) {
 	})
 }
 
+func TestHealthChecker_Handler_HighImportanceDegraded(t *testing.T) {
+	t.Parallel()
+
+	runHandlerTestCase(t, handlerTestCase{
+		targets: []HealthTarget{
+			{
+				Name:       "test1",
+				Importance: TargetImportanceHigh,
+				check: check.CheckFunc(func(ctx context.Context) error {
+					return check.Degraded(errors.New("slow response"))
+				}),
+			},
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: []map[string]interface{}{
+			{
+				"target": map[string]interface{}{
+					"name":       "test1",
+					"importance": "high",
+				},
+				"status":   "degraded",
+				"error":    "slow response",
+				"duration": float64(0),
+			},
+		},
+	})
+}
+
+func TestHealthChecker_Handler_ResultDetails(t *testing.T) {
+	t.Parallel()
+
+	diskCheck := check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
+		return check.Result{
+			Details:       map[string]any{"path": "/var"},
+			ObservedValue: 1.5,
+			Unit:          "GB",
+		}, errors.New("insufficient disk space")
+	})
+
+	checker := NewHealthChecker().
+		WithTarget("disk", check.WithRetries(check.WithTimeout(diskCheck, time.Second), 1, 0))
+	recorder := executeHandlerRequest(t, checker, "")
+
+	assertStatusCode(t, http.StatusInternalServerError, recorder.Code)
+
+	var response []struct {
+		Details       map[string]any `json:"details"`
+		ObservedValue float64        `json:"observed_value"`
+		ObservedUnit  string         `json:"observed_unit"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response[0].ObservedValue != 1.5 || response[0].ObservedUnit != "GB" || response[0].Details["path"] != "/var" {
+		t.Fatalf("expected result of wrapped check to be preserved, got %+v", response[0])
+	}
+}
+
 func TestHealthChecker_Check_NoTargets(t *testing.T) {
 	t.Parallel()
 
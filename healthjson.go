@@ -0,0 +1,150 @@
+package status
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// healthJSONContentType is the media type of the IETF health check response format.
+	healthJSONContentType = "application/health+json"
+	// healthJSONResponseTime is the measurement name used for check durations.
+	healthJSONResponseTime = "responseTime"
+	// healthJSONUnitMilliseconds is the unit of check durations.
+	healthJSONUnitMilliseconds = "ms"
+	// healthJSONObservedValue is the measurement name of observed values reported
+	// without a measurement or a unit.
+	healthJSONObservedValue = "observedValue"
+)
+
+// Statuses of the IETF health check response format.
+const (
+	healthJSONPass = "pass"
+	healthJSONWarn = "warn"
+	healthJSONFail = "fail"
+)
+
+// ServiceInfo describes the service in the application/health+json response.
+type ServiceInfo struct {
+	Version     string
+	ReleaseID   string
+	ServiceID   string
+	Description string
+}
+
+// healthJSONResponse is the top-level object of the IETF health check response format
+// (draft-inadarei-api-health-check).
+type healthJSONResponse struct {
+	Status      string                       `json:"status"`
+	Version     string                       `json:"version,omitempty"`
+	ReleaseID   string                       `json:"releaseId,omitempty"`
+	ServiceID   string                       `json:"serviceId,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Checks      map[string][]healthJSONCheck `json:"checks,omitempty"`
+}
+
+// healthJSONCheck is a single measurement of a component in the IETF health check response format.
+type healthJSONCheck struct {
+	ObservedValue any       `json:"observedValue,omitempty"`
+	ObservedUnit  string    `json:"observedUnit,omitempty"`
+	Status        string    `json:"status"`
+	Time          time.Time `json:"time"`
+	Output        string    `json:"output,omitempty"`
+}
+
+// HealthJSONHandler returns an HTTP handler that responds with the IETF health check
+// response format (draft-inadarei-api-health-check) using the application/health+json
+// content type. Every target is reported as a "<name>:responseTime" check, and targets
+// reporting an observed value (see check.Result) additionally as a "<name>" check.
+func (c *HealthChecker) HealthJSONHandler(info ServiceInfo) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, err := c.Check(r.Context())
+		if err != nil {
+			respond(w, healthJSONContentType, http.StatusInternalServerError, healthJSONResponse{
+				Status:      healthJSONFail,
+				Version:     info.Version,
+				ReleaseID:   info.ReleaseID,
+				ServiceID:   info.ServiceID,
+				Description: info.Description,
+			})
+
+			return
+		}
+
+		response := newHealthJSONResponse(info, c.visibleResults(r, results))
+		respond(w, healthJSONContentType, resultsStatusCode(results), response)
+	})
+}
+
+// newHealthJSONResponse converts check results into the IETF health check response format.
+func newHealthJSONResponse(info ServiceInfo, results []HealthCheckResult) healthJSONResponse {
+	response := healthJSONResponse{
+		Status:      conclusionHealthJSONStatus(calculateConclusion(results)),
+		Version:     info.Version,
+		ReleaseID:   info.ReleaseID,
+		ServiceID:   info.ServiceID,
+		Description: info.Description,
+		Checks:      make(map[string][]healthJSONCheck, len(results)),
+	}
+
+	for _, result := range results {
+		key := result.Target.Name + ":" + healthJSONResponseTime
+		response.Checks[key] = append(response.Checks[key], healthJSONCheck{
+			ObservedValue: float64(result.Duration) / float64(time.Millisecond),
+			ObservedUnit:  healthJSONUnitMilliseconds,
+			Status:        targetHealthJSONStatus(result.Status),
+			Time:          result.CheckedAt,
+			Output:        result.ErrorMessage,
+		})
+
+		if result.ObservedValue != nil {
+			key := result.Target.Name + ":" + observedMeasurement(result)
+			response.Checks[key] = append(response.Checks[key], healthJSONCheck{
+				ObservedValue: result.ObservedValue,
+				ObservedUnit:  result.ObservedUnit,
+				Status:        targetHealthJSONStatus(result.Status),
+				Time:          result.CheckedAt,
+				Output:        result.ErrorMessage,
+			})
+		}
+	}
+
+	return response
+}
+
+// observedMeasurement returns the measurement name of the observed value of the
+// result, falling back to its unit.
+func observedMeasurement(result HealthCheckResult) string {
+	switch {
+	case result.Measurement != "":
+		return result.Measurement
+	case result.ObservedUnit != "":
+		return result.ObservedUnit
+	default:
+		return healthJSONObservedValue
+	}
+}
+
+// conclusionHealthJSONStatus maps a Conclusion to a status of the IETF health check response format.
+func conclusionHealthJSONStatus(conclusion Conclusion) string {
+	switch conclusion {
+	case ConclusionOk:
+		return healthJSONPass
+	case ConclusionWarning:
+		return healthJSONWarn
+	default:
+		return healthJSONFail
+	}
+}
+
+// targetHealthJSONStatus maps a HealthTargetStatus to a status of the IETF health check response format.
+func targetHealthJSONStatus(status HealthTargetStatus) string {
+	switch status {
+	case HealthTargetStatusOk, HealthTargetStatusMaintenance:
+		return healthJSONPass
+	case HealthTargetStatusDegraded:
+		return healthJSONWarn
+	default:
+		return healthJSONFail
+	}
+}
@@ -0,0 +1,85 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_HealthJSONHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		})).
+		WithTarget("cache", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("cache miss")
+		}), WithImportance(TargetImportanceLow)).
+		WithTarget("disk", check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
+			return check.Result{ObservedValue: 42.5, Unit: "GB", Measurement: "freeSpace"}, nil
+		})).
+		WithTarget("queue", check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
+			return check.Result{ObservedValue: 7, Unit: "messages"}, nil
+		}))
+
+	w := httptest.NewRecorder()
+	checker.HealthJSONHandler(ServiceInfo{Version: "1", ReleaseID: "1.2.3", ServiceID: "orders"}).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assertStatusCode(t, http.StatusOK, w.Code)
+	if got := w.Header().Get("Content-Type"); got != "application/health+json" {
+		t.Fatalf("unexpected content type %q", got)
+	}
+
+	var response struct {
+		Status    string `json:"status"`
+		Version   string `json:"version"`
+		ReleaseID string `json:"releaseId"`
+		ServiceID string `json:"serviceId"`
+		Checks    map[string][]struct {
+			ObservedValue *float64 `json:"observedValue"`
+			ObservedUnit  string   `json:"observedUnit"`
+			Status        string   `json:"status"`
+			Time          string   `json:"time"`
+			Output        string   `json:"output"`
+		} `json:"checks"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Status != "warn" || response.Version != "1" || response.ReleaseID != "1.2.3" ||
+		response.ServiceID != "orders" {
+		t.Fatalf("unexpected top-level fields: %+v", response)
+	}
+
+	postgres := response.Checks["postgres:responseTime"]
+	if len(postgres) != 1 || postgres[0].Status != "pass" || postgres[0].ObservedUnit != "ms" ||
+		postgres[0].ObservedValue == nil || postgres[0].Time == "" {
+		t.Fatalf("unexpected postgres check: %+v", postgres)
+	}
+
+	cache := response.Checks["cache:responseTime"]
+	if len(cache) != 1 || cache[0].Status != "fail" || cache[0].Output != "cache miss" {
+		t.Fatalf("unexpected cache check: %+v", cache)
+	}
+
+	disk := response.Checks["disk:freeSpace"]
+	if len(disk) != 1 || disk[0].Status != "pass" || disk[0].ObservedUnit != "GB" ||
+		disk[0].ObservedValue == nil || *disk[0].ObservedValue != 42.5 {
+		t.Fatalf("unexpected disk check: %+v", disk)
+	}
+
+	if queue := response.Checks["queue:messages"]; len(queue) != 1 || *queue[0].ObservedValue != 7 {
+		t.Fatalf("expected the observed value to be keyed by its unit, got %+v", queue)
+	}
+	if _, ok := response.Checks["disk"]; ok {
+		t.Fatal("expected no check keyed by the bare target name")
+	}
+}
@@ -0,0 +1,208 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultHistorySize is the number of entries kept per target unless overridden with WithHistorySize.
+	defaultHistorySize = 1000
+	// latencyPercentile is the percentile reported as TargetStats.P95Latency.
+	latencyPercentile = 0.95
+	// percentMultiplier converts a ratio into a percentage.
+	percentMultiplier = 100
+)
+
+// Common windows for TargetStats.
+const (
+	WindowHour  = time.Hour
+	WindowDay   = 24 * time.Hour
+	WindowWeek  = 7 * WindowDay
+	WindowMonth = 30 * WindowDay
+)
+
+// ErrUnknownTarget is returned when a target name is not registered in the HealthChecker.
+var ErrUnknownTarget = errors.New("unknown target")
+
+// HistoryEntry is a single recorded health check run of a target.
+type HistoryEntry struct {
+	Time     time.Time          `json:"time"`
+	Status   HealthTargetStatus `json:"status"`
+	Duration time.Duration      `json:"duration"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// TargetStats contains uptime and latency statistics of a target over a time window.
+// Uptime is a percentage in the range [0, 100]. All values are zero when no
+// checks were recorded within the window.
+//
+// Since is the time of the oldest check within the window. It is later than the
+// start of the window when the window is not fully covered, for example when the
+// bounded in-memory history holds fewer runs than the window spans.
+type TargetStats struct {
+	Window      time.Duration `json:"window"`
+	Since       time.Time     `json:"since"`
+	Checks      int           `json:"checks"`
+	Failures    int           `json:"failures"`
+	Uptime      float64       `json:"uptime"`
+	MeanLatency time.Duration `json:"mean_latency"`
+	P95Latency  time.Duration `json:"p95_latency"`
+}
+
+// history is a bounded, concurrency safe ring buffer of history entries.
+type history struct {
+	mu      sync.RWMutex
+	entries []HistoryEntry
+	next    int
+	full    bool
+}
+
+func newHistory(size int) *history {
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+
+	return &history{entries: make([]HistoryEntry, size)}
+}
+
+// add appends an entry, overwriting the oldest one when the buffer is full.
+func (h *history) add(entry HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns the recorded entries in chronological order.
+func (h *history) list() []HistoryEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if !h.full {
+		return append([]HistoryEntry(nil), h.entries[:h.next]...)
+	}
+
+	entries := make([]HistoryEntry, 0, len(h.entries))
+	entries = append(entries, h.entries[h.next:]...)
+	entries = append(entries, h.entries[:h.next]...)
+
+	return entries
+}
+
+// newHistoryEntry converts a check result into a history entry.
+func newHistoryEntry(result HealthCheckResult) HistoryEntry {
+	return HistoryEntry{
+		Time:     result.CheckedAt,
+		Status:   result.Status,
+		Duration: result.Duration,
+		Error:    result.ErrorMessage,
+	}
+}
+
+// History returns the recorded check runs of the named target in chronological order.
+// The number of entries is bounded by WithHistorySize.
+func (c *HealthChecker) History(name string) ([]HistoryEntry, error) {
+	index, err := c.targetIndex(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.histories[index].list(), nil
+}
+
+// HistoryRange returns the check runs of the named target recorded within [from, to].
+// Entries are read from the HistoryStore when one is configured (see WithHistoryStore)
+// and from the bounded in-memory history otherwise.
+func (c *HealthChecker) HistoryRange(ctx context.Context, name string, from, to time.Time) ([]HistoryEntry, error) {
+	index, err := c.targetIndex(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.store == nil {
+		return filterEntries(c.histories[index].list(), from, to), nil
+	}
+
+	entries, err := c.store.Range(ctx, name, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("reading history of target %s: %w", name, err)
+	}
+
+	return entries, nil
+}
+
+// Stats computes uptime and latency statistics of the named target over the
+// given window, for example WindowDay. See HistoryRange for where the entries
+// are taken from. Without a HistoryStore, windows longer than WithHistorySize
+// runs are only partially covered, as reported by TargetStats.Since.
+func (c *HealthChecker) Stats(ctx context.Context, name string, window time.Duration) (TargetStats, error) {
+	now := time.Now()
+
+	entries, err := c.HistoryRange(ctx, name, now.Add(-window), now)
+	if err != nil {
+		return TargetStats{}, err
+	}
+
+	return computeStats(entries, window, now), nil
+}
+
+// targetIndex returns the index of the first target registered with the given name.
+func (c *HealthChecker) targetIndex(name string) (int, error) {
+	for i, target := range c.targets {
+		if target.Name == name {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrUnknownTarget, name)
+}
+
+// computeStats aggregates the entries recorded within window before now.
+func computeStats(entries []HistoryEntry, window time.Duration, now time.Time) TargetStats {
+	stats := TargetStats{Window: window}
+	since := now.Add(-window)
+
+	var total time.Duration
+	durations := make([]time.Duration, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.Time.Before(since) || entry.Time.After(now) {
+			continue
+		}
+
+		if stats.Since.IsZero() || entry.Time.Before(stats.Since) {
+			stats.Since = entry.Time
+		}
+		stats.Checks++
+		if entry.Status == HealthTargetStatusFail {
+			stats.Failures++
+		}
+		total += entry.Duration
+		durations = append(durations, entry.Duration)
+	}
+
+	if stats.Checks == 0 {
+		return stats
+	}
+
+	stats.Uptime = float64(stats.Checks-stats.Failures) / float64(stats.Checks) * percentMultiplier
+	stats.MeanLatency = total / time.Duration(stats.Checks)
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	rank := int(math.Ceil(latencyPercentile*float64(len(durations)))) - 1
+	stats.P95Latency = durations[max(rank, 0)]
+
+	return stats
+}
@@ -0,0 +1,395 @@
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// fileHistoryCompactEvery is the number of appends after which the file store compacts itself.
+	fileHistoryCompactEvery = 10000
+	// fileHistoryMaxLine is the maximum size of a single JSONL record.
+	fileHistoryMaxLine = 1 << 20
+	// fileHistoryPerm is the permission of the history file.
+	fileHistoryPerm = 0o600
+	// defaultInMemoryHistorySize is the number of entries kept per target by
+	// InMemoryHistoryStore unless set.
+	defaultInMemoryHistorySize = 10000
+)
+
+// HistoryStore persists check results of targets.
+type HistoryStore interface {
+	// Append stores a check result of the named target.
+	Append(ctx context.Context, target string, entry HistoryEntry) error
+	// Range returns the entries of the named target recorded within [from, to] in chronological order.
+	Range(ctx context.Context, target string, from, to time.Time) ([]HistoryEntry, error)
+}
+
+// InMemoryHistoryStore keeps the latest history entries of every target in
+// memory with concurrency safety.
+type InMemoryHistoryStore struct {
+	size    int
+	mu      sync.RWMutex
+	entries map[string][]HistoryEntry
+}
+
+// NewInMemoryHistoryStore constructs a new in-memory history store keeping the
+// last size entries of every target. A size of zero or less keeps 10,000 entries.
+func NewInMemoryHistoryStore(size int) *InMemoryHistoryStore {
+	if size <= 0 {
+		size = defaultInMemoryHistorySize
+	}
+
+	return &InMemoryHistoryStore{size: size, entries: make(map[string][]HistoryEntry)}
+}
+
+// Append stores the entry for the named target, dropping its oldest entry when
+// the store is full.
+func (s *InMemoryHistoryStore) Append(_ context.Context, target string, entry HistoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := append(s.entries[target], entry)
+	if len(entries) > s.size {
+		entries = entries[len(entries)-s.size:]
+	}
+	s.entries[target] = entries
+
+	return nil
+}
+
+// Range returns the entries of the named target recorded within [from, to].
+func (s *InMemoryHistoryStore) Range(_ context.Context, target string, from, to time.Time) ([]HistoryEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return filterEntries(s.entries[target], from, to), nil
+}
+
+// FileHistoryStore persists history entries as append-only JSON lines. Entries
+// older than the retention period are dropped when the file is compacted, which
+// happens on open, in the background every 10,000 appends and on Compact.
+//
+// Range reads the file without blocking Append, so that reading long windows
+// does not delay checks recording their results.
+type FileHistoryStore struct {
+	path      string
+	retention time.Duration
+
+	// mu guards appending to file and swapping it for the compacted one.
+	mu         sync.Mutex
+	file       *os.File
+	appends    int
+	closed     bool
+	compacting bool
+
+	// compactMu serialises compactions.
+	compactMu sync.Mutex
+	compactor sync.WaitGroup
+}
+
+// fileHistoryRecord is a single line of the history file.
+type fileHistoryRecord struct {
+	Target string `json:"target"`
+	HistoryEntry
+}
+
+// NewFileHistoryStore opens or creates the history file at path. A zero retention keeps entries forever.
+func NewFileHistoryStore(path string, retention time.Duration) (*FileHistoryStore, error) {
+	s := &FileHistoryStore{
+		path:      path,
+		retention: retention,
+	}
+
+	if err := s.Compact(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Append writes the entry for the named target to the end of the file.
+func (s *FileHistoryStore) Append(_ context.Context, target string, entry HistoryEntry) error {
+	line, err := json.Marshal(fileHistoryRecord{Target: target, HistoryEntry: entry})
+	if err != nil {
+		return fmt.Errorf("marshal history entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.file == nil {
+		return errors.New("history store is closed")
+	}
+
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("write history entry: %w", err)
+	}
+
+	s.appends++
+	if s.appends >= fileHistoryCompactEvery && !s.compacting {
+		s.compacting = true
+		s.compactor.Add(1)
+		go s.compactInBackground()
+	}
+
+	return nil
+}
+
+// Range returns the entries of the named target recorded within [from, to].
+func (s *FileHistoryStore) Range(ctx context.Context, target string, from, to time.Time) ([]HistoryEntry, error) {
+	records, err := s.read(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	for _, record := range records {
+		if record.Target == target {
+			entries = append(entries, record.HistoryEntry)
+		}
+	}
+
+	return filterEntries(entries, from, to), nil
+}
+
+// Compact rewrites the file without entries older than the retention period.
+func (s *FileHistoryStore) Compact(ctx context.Context) error {
+	s.compactMu.Lock()
+	defer s.compactMu.Unlock()
+
+	return s.compact(ctx)
+}
+
+// Close waits for a background compaction and closes the underlying file.
+func (s *FileHistoryStore) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.compactor.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return fmt.Errorf("close history file: %w", err)
+	}
+
+	return nil
+}
+
+// compactInBackground compacts the file after Append reached the threshold.
+func (s *FileHistoryStore) compactInBackground() {
+	defer s.compactor.Done()
+
+	if err := s.Compact(context.Background()); err != nil {
+		log.Printf("compacting history file %s: %v", s.path, err)
+	}
+
+	s.mu.Lock()
+	s.compacting = false
+	s.mu.Unlock()
+}
+
+// compact rewrites the file through a temporary file, which is then used for
+// appending. The file is read and filtered without blocking Append; entries
+// appended meanwhile are copied over before the files are swapped. It must be
+// called with compactMu held.
+func (s *FileHistoryStore) compact(ctx context.Context) error {
+	s.mu.Lock()
+	size, err := s.size()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	records, err := s.read(ctx, size)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary history file: %w", err)
+	}
+
+	// The temporary file becomes the appended file once renamed, so that the
+	// current file stays open and in place when anything fails.
+	if err := s.writeRecords(tmp, records); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return nil
+	}
+
+	if err := s.replace(tmp, size); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file = tmp
+	s.appends = 0
+
+	return nil
+}
+
+// size returns the size of the history file. It must be called with mu held,
+// so that the size ends at a complete record.
+func (s *FileHistoryStore) size() (int64, error) {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("stat history file: %w", err)
+	}
+
+	return info.Size(), nil
+}
+
+// writeRecords writes the records within the retention period to tmp.
+func (s *FileHistoryStore) writeRecords(tmp *os.File, records []fileHistoryRecord) error {
+	if err := tmp.Chmod(fileHistoryPerm); err != nil {
+		return fmt.Errorf("set history file permissions: %w", err)
+	}
+
+	var buf bytes.Buffer
+	cutoff := time.Now().Add(-s.retention)
+	for _, record := range records {
+		if s.retention > 0 && record.Time.Before(cutoff) {
+			continue
+		}
+
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("marshal history entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write temporary history file: %w", err)
+	}
+
+	return nil
+}
+
+// replace copies the records appended to the history file after offset to tmp,
+// syncs it and renames it over the history file. It must be called with mu held.
+func (s *FileHistoryStore) replace(tmp *os.File, offset int64) error {
+	if s.file != nil {
+		appended, err := os.Open(s.path)
+		if err != nil {
+			return fmt.Errorf("open history file: %w", err)
+		}
+		defer appended.Close()
+
+		if _, err := appended.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("seek history file: %w", err)
+		}
+		if _, err := io.Copy(tmp, appended); err != nil {
+			return fmt.Errorf("copy appended history entries: %w", err)
+		}
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("sync temporary history file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replace history file: %w", err)
+	}
+
+	return nil
+}
+
+// read parses the records within the first limit bytes of the history file, or
+// of the whole file when limit is negative. Malformed lines, such as a record
+// truncated by a crash, are skipped.
+func (s *FileHistoryStore) read(ctx context.Context, limit int64) ([]fileHistoryRecord, error) {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history file: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if limit >= 0 {
+		reader = io.LimitReader(file, limit)
+	}
+
+	var records []fileHistoryRecord
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, fileHistoryMaxLine)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("read history file: %w", err)
+		}
+
+		var record fileHistoryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("scan history file: %w", err)
+	}
+
+	return records, nil
+}
+
+// filterEntries returns the entries recorded within [from, to] in chronological order.
+func filterEntries(entries []HistoryEntry, from, to time.Time) []HistoryEntry {
+	filtered := make([]HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Time.Before(from) || entry.Time.After(to) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+
+	return filtered
+}
@@ -0,0 +1,215 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestInMemoryHistoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryHistoryStore(0)
+	now := time.Now()
+
+	for i := range 3 {
+		entry := HistoryEntry{Time: now.Add(-time.Duration(i) * time.Hour), Status: HealthTargetStatusOk}
+		if err := store.Append(context.Background(), "redis", entry); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	entries, err := store.Range(context.Background(), "redis", now.Add(-90*time.Minute), now)
+	if err != nil {
+		t.Fatalf("range: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if !entries[0].Time.Before(entries[1].Time) {
+		t.Fatalf("expected chronological order, got %v", entries)
+	}
+}
+
+func TestInMemoryHistoryStore_Size(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryHistoryStore(2)
+	now := time.Now()
+
+	for i := range 3 {
+		entry := HistoryEntry{Time: now.Add(time.Duration(i) * time.Minute)}
+		if err := store.Append(context.Background(), "redis", entry); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	entries, _ := store.Range(context.Background(), "redis", now.Add(-time.Hour), now.Add(time.Hour))
+	if len(entries) != 2 || !entries[0].Time.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected the 2 latest entries, got %+v", entries)
+	}
+}
+
+func TestFileHistoryStore_PersistsAcrossReopen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+
+	store, err := NewFileHistoryStore(path, 0)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+
+	entries := []struct {
+		target string
+		entry  HistoryEntry
+	}{
+		{"redis", HistoryEntry{Time: now, Status: HealthTargetStatusOk, Duration: time.Millisecond}},
+		{"postgres", HistoryEntry{Time: now, Status: HealthTargetStatusFail, Error: "refused"}},
+	}
+	for _, e := range entries {
+		if err := store.Append(context.Background(), e.target, e.entry); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reopened, err := NewFileHistoryStore(path, 0)
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.Range(context.Background(), "postgres", now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("range: %v", err)
+	}
+	if len(got) != 1 || got[0].Error != "refused" || got[0].Status != HealthTargetStatusFail {
+		t.Fatalf("unexpected entries: %+v", got)
+	}
+}
+
+func TestFileHistoryStore_CompactsExpiredAndMalformed(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := NewFileHistoryStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	_ = store.Append(context.Background(), "redis", HistoryEntry{Time: now.Add(-2 * time.Hour)})
+	_ = store.Append(context.Background(), "redis", HistoryEntry{Time: now})
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("open file: %v", err)
+	}
+	_, _ = file.WriteString(`{"target":"redis","ti`)
+	file.Close()
+
+	if err := store.Compact(context.Background()); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+
+	records, err := store.read(context.Background(), -1)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(records) != 1 || !records[0].Time.Equal(now) {
+		t.Fatalf("expected only the recent entry to survive, got %+v", records)
+	}
+	if err := store.Append(context.Background(), "redis", HistoryEntry{Time: now}); err != nil {
+		t.Fatalf("append after compact: %v", err)
+	}
+	if records, _ := store.read(context.Background(), -1); len(records) != 2 {
+		t.Fatalf("expected appends to reach the compacted file, got %+v", records)
+	}
+}
+
+func TestHealthChecker_WithHistoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryHistoryStore(0)
+	checker := NewHealthChecker(WithHistoryStore(store)).
+		WithTarget("redis", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	stats, err := checker.Stats(context.Background(), "redis", WindowDay)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Checks != 1 || stats.Uptime != 100 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestFileHistoryStore_RangeDoesNotBlockAppend(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileHistoryStore(filepath.Join(t.TempDir(), "history.jsonl"), 0)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		_, _ = store.Range(context.Background(), "redis", time.Time{}, time.Now())
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Range not to wait for the append lock")
+	}
+}
+
+func TestFileHistoryStore_CompactsInBackground(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := NewFileHistoryStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+
+	now := time.Now()
+	total := fileHistoryCompactEvery + 100
+	for range total {
+		if err := store.Append(context.Background(), "redis", HistoryEntry{Time: now}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	records, err := store.read(context.Background(), -1)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(records) != total {
+		t.Fatalf("expected %d entries to survive compaction, got %d", total, len(records))
+	}
+}
@@ -0,0 +1,117 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHistory_Bounded(t *testing.T) {
+	t.Parallel()
+
+	h := newHistory(3)
+	base := time.Now()
+	for i := range 5 {
+		h.add(HistoryEntry{Time: base.Add(time.Duration(i) * time.Second)})
+	}
+
+	entries := h.list()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	for i, entry := range entries {
+		want := base.Add(time.Duration(i+2) * time.Second)
+		if !entry.Time.Equal(want) {
+			t.Fatalf("entry[%d]: expected time %v, got %v", i, want, entry.Time)
+		}
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	entries := []HistoryEntry{
+		{Time: now.Add(-2 * WindowHour), Status: HealthTargetStatusFail, Duration: time.Second},
+		{Time: now.Add(-50 * time.Minute), Status: HealthTargetStatusOk, Duration: 10 * time.Millisecond},
+		{Time: now.Add(-40 * time.Minute), Status: HealthTargetStatusOk, Duration: 20 * time.Millisecond},
+		{Time: now.Add(-30 * time.Minute), Status: HealthTargetStatusFail, Duration: 30 * time.Millisecond},
+		{Time: now.Add(-20 * time.Minute), Status: HealthTargetStatusOk, Duration: 40 * time.Millisecond},
+	}
+
+	stats := computeStats(entries, WindowHour, now)
+
+	if stats.Checks != 4 {
+		t.Fatalf("expected 4 checks, got %d", stats.Checks)
+	}
+	if stats.Failures != 1 {
+		t.Fatalf("expected 1 failure, got %d", stats.Failures)
+	}
+	if stats.Uptime != 75 {
+		t.Fatalf("expected uptime 75, got %v", stats.Uptime)
+	}
+	if stats.MeanLatency != 25*time.Millisecond {
+		t.Fatalf("expected mean latency 25ms, got %v", stats.MeanLatency)
+	}
+	if stats.P95Latency != 40*time.Millisecond {
+		t.Fatalf("expected p95 latency 40ms, got %v", stats.P95Latency)
+	}
+	if !stats.Since.Equal(entries[1].Time) {
+		t.Fatalf("expected stats since %v, got %v", entries[1].Time, stats.Since)
+	}
+
+	if empty := computeStats(nil, WindowDay, now); empty.Checks != 0 || empty.Uptime != 0 {
+		t.Fatalf("expected empty stats, got %+v", empty)
+	}
+}
+
+func TestHealthChecker_History(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	checker := NewHealthChecker(WithHistorySize(10)).
+		WithTarget("redis", check.CheckFunc(func(ctx context.Context) error {
+			calls++
+			if calls%2 == 0 {
+				return errors.New("timeout")
+			}
+
+			return nil
+		}))
+
+	for range 4 {
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("check: %v", err)
+		}
+	}
+
+	entries, err := checker.History("redis")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+	if entries[1].Status != HealthTargetStatusFail || entries[1].Error != "timeout" {
+		t.Fatalf("unexpected entry: %+v", entries[1])
+	}
+
+	stats, err := checker.Stats(context.Background(), "redis", WindowWeek)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Uptime != 50 {
+		t.Fatalf("expected uptime 50, got %v", stats.Uptime)
+	}
+	if !stats.Since.Equal(entries[0].Time) {
+		t.Fatalf("expected the week to be covered since the first run %v, got %v", entries[0].Time, stats.Since)
+	}
+
+	if _, err := checker.Stats(context.Background(), "postgres", WindowDay); !errors.Is(err, ErrUnknownTarget) {
+		t.Fatalf("expected ErrUnknownTarget, got %v", err)
+	}
+}
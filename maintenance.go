@@ -0,0 +1,212 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidMaintenance is returned when a maintenance window is malformed.
+var ErrInvalidMaintenance = errors.New("invalid maintenance window")
+
+// MaintenanceWindow silences a target, or all targets of a group, for a time range.
+// Targets in maintenance are still checked, but are reported with the
+// HealthTargetStatusMaintenance status: they affect neither the status code of
+// Handler nor the conclusion, and status change hooks are not called for them
+// until the window ends.
+type MaintenanceWindow struct {
+	// ID identifies the window. It is assigned by AddMaintenance unless set.
+	ID string `json:"id"`
+	// Target is the name of the target in maintenance.
+	Target string `json:"target,omitempty"`
+	// Group is the name of the group in maintenance (see WithGroup).
+	Group string `json:"group,omitempty"`
+	// Start and End limit the window. For recurring windows they limit the first occurrence.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Every repeats the window with the given period, for example weekly. Zero means once.
+	Every  time.Duration `json:"every,omitempty"`
+	Reason string        `json:"reason,omitempty"`
+}
+
+// validate checks that the window selects targets and has a positive length
+// shorter than its period.
+func (w MaintenanceWindow) validate() error {
+	switch {
+	case w.Target == "" && w.Group == "":
+		return fmt.Errorf("%w: target or group is required", ErrInvalidMaintenance)
+	case w.Target != "" && w.Group != "":
+		return fmt.Errorf("%w: target and group are mutually exclusive", ErrInvalidMaintenance)
+	case !w.End.After(w.Start):
+		return fmt.Errorf("%w: end must be after start", ErrInvalidMaintenance)
+	case w.Every < 0 || (w.Every > 0 && w.Every < w.End.Sub(w.Start)):
+		return fmt.Errorf("%w: every must not be shorter than the window", ErrInvalidMaintenance)
+	}
+
+	return nil
+}
+
+// occurrence returns the occurrence of the window active at the given time.
+func (w MaintenanceWindow) occurrence(now time.Time) (MaintenanceWindow, bool) {
+	if now.Before(w.Start) {
+		return w, false
+	}
+
+	if w.Every > 0 {
+		shift := now.Sub(w.Start) / w.Every * w.Every
+		w.Start = w.Start.Add(shift)
+		w.End = w.End.Add(shift)
+	}
+
+	return w, now.Before(w.End)
+}
+
+// matches reports whether the window applies to the target.
+func (w MaintenanceWindow) matches(target HealthTarget) bool {
+	if w.Target != "" {
+		return w.Target == target.Name
+	}
+
+	return w.Group == target.Group
+}
+
+// AddMaintenance schedules a maintenance window and returns its ID. A window
+// with an ID keeps it, for example when windows are copied to another
+// HealthChecker, and is rejected when the ID is taken.
+func (c *HealthChecker) AddMaintenance(window MaintenanceWindow) (string, error) {
+	if err := window.validate(); err != nil {
+		return "", err
+	}
+
+	c.maintenanceMu.Lock()
+	defer c.maintenanceMu.Unlock()
+
+	taken := func(id string) bool {
+		return slices.ContainsFunc(c.maintenance, func(w MaintenanceWindow) bool {
+			return w.ID == id
+		})
+	}
+
+	if window.ID != "" && taken(window.ID) {
+		return "", fmt.Errorf("%w: id %q is taken", ErrInvalidMaintenance, window.ID)
+	}
+	for window.ID == "" || taken(window.ID) {
+		c.maintenanceSeq++
+		window.ID = strconv.Itoa(c.maintenanceSeq)
+	}
+	c.maintenance = append(c.maintenance, window)
+
+	return window.ID, nil
+}
+
+// RemoveMaintenance removes the maintenance window with the given ID and reports
+// whether it existed. Status changes held back by the window are reported right away.
+func (c *HealthChecker) RemoveMaintenance(id string) bool {
+	c.maintenanceMu.Lock()
+	index := slices.IndexFunc(c.maintenance, func(w MaintenanceWindow) bool {
+		return w.ID == id
+	})
+	if index >= 0 {
+		c.maintenance = slices.Delete(c.maintenance, index, index+1)
+	}
+	c.maintenanceMu.Unlock()
+
+	if index < 0 {
+		return false
+	}
+	c.notifyPending(context.Background())
+
+	return true
+}
+
+// Maintenance returns the scheduled maintenance windows, including ones that
+// are not active at the moment.
+func (c *HealthChecker) Maintenance() []MaintenanceWindow {
+	c.maintenanceMu.RLock()
+	defer c.maintenanceMu.RUnlock()
+
+	return slices.Clone(c.maintenance)
+}
+
+// activeMaintenance returns the occurrence of the first maintenance window
+// applying to the target at the given time.
+func (c *HealthChecker) activeMaintenance(target HealthTarget, now time.Time) (MaintenanceWindow, bool) {
+	c.maintenanceMu.RLock()
+	defer c.maintenanceMu.RUnlock()
+
+	for _, window := range c.maintenance {
+		if !window.matches(target) {
+			continue
+		}
+		if occurrence, ok := window.occurrence(now); ok {
+			return occurrence, true
+		}
+	}
+
+	return MaintenanceWindow{}, false
+}
+
+// applyMaintenance reports the results of targets in maintenance with the
+// maintenance status. The outcome of the check is kept in the error message.
+func (c *HealthChecker) applyMaintenance(results []HealthCheckResult, now time.Time) []HealthCheckResult {
+	for i, result := range results {
+		window, ok := c.activeMaintenance(result.Target, now)
+		if !ok {
+			continue
+		}
+
+		results[i].Status = HealthTargetStatusMaintenance
+		results[i].Maintenance = &window
+	}
+
+	return results
+}
+
+// MaintenanceHandler returns an HTTP handler managing maintenance windows:
+//
+//   - GET lists the scheduled windows;
+//   - POST schedules the MaintenanceWindow in the JSON body and responds with it;
+//   - DELETE removes the window given by the id query parameter.
+//
+// The handler performs no authentication; AdminHandler serves it behind an Authorizer.
+func (c *HealthChecker) MaintenanceHandler() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			respondJSON(w, http.StatusOK, c.Maintenance())
+		case http.MethodPost:
+			var window MaintenanceWindow
+			if err := json.NewDecoder(r.Body).Decode(&window); err != nil {
+				http.Error(w, fmt.Sprintf("decoding maintenance window: %v", err), http.StatusBadRequest)
+
+				return
+			}
+
+			id, err := c.AddMaintenance(window)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+			window.ID = id
+
+			respondJSON(w, http.StatusCreated, window)
+		case http.MethodDelete:
+			if !c.RemoveMaintenance(r.URL.Query().Get("id")) {
+				http.Error(w, "maintenance window not found", http.StatusNotFound)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_Maintenance_Target(t *testing.T) {
+	t.Parallel()
+
+	var events []StatusChangeEvent
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("refused")
+		})).
+		WithTarget("redis", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		})).
+		OnStatusChange(func(_ context.Context, event StatusChangeEvent) {
+			events = append(events, event)
+		})
+
+	now := time.Now()
+	id, err := checker.AddMaintenance(MaintenanceWindow{
+		Target: "postgres",
+		Start:  now.Add(-time.Minute),
+		End:    now.Add(time.Hour),
+		Reason: "upgrade",
+	})
+	if err != nil {
+		t.Fatalf("add maintenance: %v", err)
+	}
+
+	rec := executeHandlerRequest(t, checker, "")
+	assertStatusCode(t, http.StatusOK, rec.Code)
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusMaintenance || results[0].Maintenance == nil ||
+		results[0].Maintenance.Reason != "upgrade" || results[0].ErrorMessage != "refused" {
+		t.Fatalf("unexpected result in maintenance: %+v", results[0])
+	}
+	if results[1].Status != HealthTargetStatusOk || results[1].Maintenance != nil {
+		t.Fatalf("unexpected result outside maintenance: %+v", results[1])
+	}
+	if conclusion := calculateConclusion(results); conclusion != ConclusionOk {
+		t.Fatalf("expected conclusion %q, got %q", ConclusionOk, conclusion)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no status events in maintenance, got %+v", events)
+	}
+
+	if !checker.RemoveMaintenance(id) {
+		t.Fatal("expected maintenance window to be removed")
+	}
+	rec = executeHandlerRequest(t, checker, "")
+	assertStatusCode(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHealthChecker_Maintenance_Group(t *testing.T) {
+	t.Parallel()
+
+	failing := check.CheckFunc(func(ctx context.Context) error {
+		return errors.New("refused")
+	})
+	checker := NewHealthChecker().
+		WithTarget("postgres", failing, WithGroup("Databases")).
+		WithTarget("api", failing, WithGroup("Services"))
+
+	now := time.Now()
+	if _, err := checker.AddMaintenance(MaintenanceWindow{
+		Group: "Databases",
+		Start: now.Add(-time.Minute),
+		End:   now.Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("add maintenance: %v", err)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusMaintenance || results[1].Status != HealthTargetStatusFail {
+		t.Fatalf("expected only the Databases group in maintenance, got %q and %q",
+			results[0].Status, results[1].Status)
+	}
+}
+
+func TestMaintenanceWindow_Occurrence(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.January, 7, 2, 0, 0, 0, time.UTC)
+	window := MaintenanceWindow{
+		Target: "postgres",
+		Start:  start,
+		End:    start.Add(time.Hour),
+		Every:  7 * 24 * time.Hour,
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		active bool
+	}{
+		{name: "before first", now: start.Add(-time.Minute), active: false},
+		{name: "first", now: start.Add(30 * time.Minute), active: true},
+		{name: "between", now: start.Add(2 * time.Hour), active: false},
+		{name: "later week", now: start.Add(3*7*24*time.Hour + 59*time.Minute), active: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			occurrence, active := window.occurrence(tt.now)
+			if active != tt.active {
+				t.Fatalf("expected active %v, got %v", tt.active, active)
+			}
+			if active && (tt.now.Before(occurrence.Start) || !tt.now.Before(occurrence.End)) {
+				t.Fatalf("occurrence %v-%v does not contain %v", occurrence.Start, occurrence.End, tt.now)
+			}
+		})
+	}
+}
+
+func TestHealthChecker_AddMaintenance_Invalid(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	windows := map[string]MaintenanceWindow{
+		"no target":      {Start: now, End: now.Add(time.Hour)},
+		"target & group": {Target: "a", Group: "b", Start: now, End: now.Add(time.Hour)},
+		"empty range":    {Target: "a", Start: now, End: now},
+		"short period":   {Target: "a", Start: now, End: now.Add(time.Hour), Every: time.Minute},
+	}
+
+	checker := NewHealthChecker()
+	for name, window := range windows {
+		if _, err := checker.AddMaintenance(window); !errors.Is(err, ErrInvalidMaintenance) {
+			t.Errorf("%s: expected ErrInvalidMaintenance, got %v", name, err)
+		}
+	}
+
+	window := MaintenanceWindow{ID: "upgrade", Target: "a", Start: now, End: now.Add(time.Hour)}
+	if id, err := checker.AddMaintenance(window); err != nil || id != "upgrade" {
+		t.Fatalf("expected the window to keep its id, got %q, %v", id, err)
+	}
+	if _, err := checker.AddMaintenance(window); !errors.Is(err, ErrInvalidMaintenance) {
+		t.Fatalf("expected ErrInvalidMaintenance for a taken id, got %v", err)
+	}
+}
+
+func TestHealthChecker_MaintenanceHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker()
+	handler := checker.MaintenanceHandler()
+
+	body := `{"target":"postgres","start":"2024-01-07T02:00:00Z","end":"2024-01-07T03:00:00Z","reason":"upgrade"}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/maintenance", strings.NewReader(body)))
+	assertStatusCode(t, http.StatusCreated, rec.Code)
+
+	var created MaintenanceWindow
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode created window: %v", err)
+	}
+	if created.ID == "" || created.Reason != "upgrade" {
+		t.Fatalf("unexpected created window: %+v", created)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/maintenance", strings.NewReader(`{}`)))
+	assertStatusCode(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/maintenance", nil))
+	assertStatusCode(t, http.StatusOK, rec.Code)
+
+	var windows []MaintenanceWindow
+	if err := json.NewDecoder(rec.Body).Decode(&windows); err != nil {
+		t.Fatalf("decode windows: %v", err)
+	}
+	if len(windows) != 1 || windows[0].ID != created.ID {
+		t.Fatalf("unexpected windows: %+v", windows)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/maintenance?id="+created.ID, nil))
+	assertStatusCode(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/maintenance?id="+created.ID, nil))
+	assertStatusCode(t, http.StatusNotFound, rec.Code)
+
+	if len(checker.Maintenance()) != 0 {
+		t.Fatalf("expected no windows, got %+v", checker.Maintenance())
+	}
+}
+
+func TestHealthChecker_Maintenance_ReportsChangesAfterWindow(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	var events []StatusChangeEvent
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			if failing.Load() {
+				return errors.New("refused")
+			}
+
+			return nil
+		})).
+		OnStatusChange(func(_ context.Context, event StatusChangeEvent) {
+			events = append(events, event)
+		})
+
+	runCheck := func() {
+		t.Helper()
+
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("check: %v", err)
+		}
+	}
+
+	runCheck()
+
+	now := time.Now()
+	removed, err := checker.AddMaintenance(MaintenanceWindow{
+		Target: "postgres",
+		Start:  now.Add(-time.Minute),
+		End:    now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("add maintenance: %v", err)
+	}
+
+	failing.Store(true)
+	runCheck()
+	if len(events) != 0 {
+		t.Fatalf("expected no status events in maintenance, got %+v", events)
+	}
+
+	checker.RemoveMaintenance(removed)
+	if len(events) != 1 || events[0].Previous != HealthTargetStatusOk || events[0].Current != HealthTargetStatusFail {
+		t.Fatalf("expected the failure to be reported when the window is removed, got %+v", events)
+	}
+
+	if _, err := checker.AddMaintenance(MaintenanceWindow{
+		Target: "postgres",
+		Start:  time.Now().Add(-time.Minute),
+		End:    time.Now().Add(20 * time.Millisecond),
+	}); err != nil {
+		t.Fatalf("add maintenance: %v", err)
+	}
+
+	failing.Store(false)
+	runCheck()
+	if len(events) != 1 {
+		t.Fatalf("expected no status events in maintenance, got %+v", events)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	runCheck()
+	if len(events) != 2 || events[1].Previous != HealthTargetStatusFail || events[1].Current != HealthTargetStatusOk {
+		t.Fatalf("expected the recovery to be reported after the window ended, got %+v", events)
+	}
+}
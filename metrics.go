@@ -0,0 +1,100 @@
+package status
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// metricsContentType is the media type of the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// MetricsHandler returns an HTTP handler that exposes the health check results
+// in the Prometheus text exposition format:
+//
+//   - status_target_up{name,group,importance} is 1 unless the target failed;
+//   - status_target_check_duration_seconds{name,group,importance} is the duration of the latest check;
+//   - status_target_check_failures_total{name,group,importance} counts failed checks;
+//   - status_conclusion{conclusion} is 1 for the current Conclusion and 0 otherwise.
+func (c *HealthChecker) MetricsHandler() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, err := c.Check(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error checking health: %v", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", metricsContentType)
+		c.writeMetrics(w, results)
+	})
+}
+
+// writeMetrics writes the metrics of the given results. The results must be
+// in registration order of all targets.
+func (c *HealthChecker) writeMetrics(w io.Writer, results []HealthCheckResult) {
+	writeMetricHeader(w, "status_target_up", "gauge",
+		"Whether the health check target is up (1) or failed (0).")
+	for _, result := range results {
+		up := 1.0
+		if result.Status == HealthTargetStatusFail {
+			up = 0
+		}
+		writeMetric(w, "status_target_up", targetLabels(result.Target), up)
+	}
+
+	writeMetricHeader(w, "status_target_check_duration_seconds", "gauge",
+		"Duration of the latest health check of the target in seconds.")
+	for _, result := range results {
+		writeMetric(w, "status_target_check_duration_seconds", targetLabels(result.Target), result.Duration.Seconds())
+	}
+
+	writeMetricHeader(w, "status_target_check_failures_total", "counter",
+		"Total number of failed health checks of the target.")
+	for i, result := range results {
+		failures := float64(c.states[i].failuresTotal.Load())
+		writeMetric(w, "status_target_check_failures_total", targetLabels(result.Target), failures)
+	}
+
+	writeMetricHeader(w, "status_conclusion", "gauge",
+		"Overall conclusion of the health checker, 1 for the current conclusion.")
+	conclusion := calculateConclusion(results)
+	for _, candidate := range []Conclusion{ConclusionOk, ConclusionWarning, ConclusionFail} {
+		value := 0.0
+		if candidate == conclusion {
+			value = 1
+		}
+		writeMetric(w, "status_conclusion", [][2]string{{"conclusion", string(candidate)}}, value)
+	}
+}
+
+// targetLabels returns the metric labels of a target.
+func targetLabels(target HealthTarget) [][2]string {
+	return [][2]string{
+		{"name", target.Name},
+		{"group", target.Group},
+		{"importance", string(target.Importance)},
+	}
+}
+
+// writeMetricHeader writes the HELP and TYPE lines of a metric family.
+func writeMetricHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeMetric writes a single sample line.
+func writeMetric(w io.Writer, name string, labels [][2]string, value float64) {
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, label[0]+`="`+escapeLabelValue(label[1])+`"`)
+	}
+
+	fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(pairs, ","), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// escapeLabelValue escapes a label value according to the text exposition format.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,53 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_MetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}), WithGroup("Infra")).
+		WithTarget(`cache "eu"`, check.CheckFunc(func(ctx context.Context) error {
+			return errors.New("cache miss")
+		}), WithImportance(TargetImportanceLow))
+
+	handler := checker.MetricsHandler()
+
+	var body string
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assertStatusCode(t, http.StatusOK, w.Code)
+		body = w.Body.String()
+	}
+
+	expected := []string{
+		"# TYPE status_target_up gauge",
+		`status_target_up{name="postgres",group="Infra",importance="high"} 1`,
+		`status_target_up{name="cache \"eu\"",group="",importance="low"} 0`,
+		"# TYPE status_target_check_duration_seconds gauge",
+		`status_target_check_duration_seconds{name="postgres",group="Infra",importance="high"} `,
+		"# TYPE status_target_check_failures_total counter",
+		`status_target_check_failures_total{name="postgres",group="Infra",importance="high"} 0`,
+		`status_target_check_failures_total{name="cache \"eu\"",group="",importance="low"} 2`,
+		`status_conclusion{conclusion="Ok"} 0`,
+		`status_conclusion{conclusion="Warning"} 1`,
+		`status_conclusion{conclusion="Fail"} 0`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+const (
+	// defaultEmailBatchWindow is how long Email collects status changes into one digest.
+	defaultEmailBatchWindow = 30 * time.Second
+	// smtpPermanentFailure is the lowest SMTP reply code of permanent failures.
+	smtpPermanentFailure = 500
+)
+
+var (
+	//go:embed email.html.tmpl
+	emailHTMLTemplateContent string
+	//go:embed email.txt.tmpl
+	emailTextTemplateContent string
+)
+
+// EmailConfig holds the SMTP settings of the Email notifier.
+type EmailConfig struct {
+	// Addr is the host:port of the SMTP server.
+	Addr string
+	From string
+	To   []string
+	// Username and Password enable AUTH PLAIN when set. The credentials are
+	// only sent over TLS or to a server on localhost.
+	Username string
+	Password string
+	// RequireStartTLS fails delivery when the server does not offer STARTTLS.
+	// STARTTLS is used whenever the server offers it.
+	RequireStartTLS bool
+	// TLSConfig is used for STARTTLS. Defaults to verifying the server host name.
+	TLSConfig *tls.Config
+	// Timeout bounds connecting to the server and each delivery attempt when the
+	// context has no deadline. Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// Email sends a plain-text and HTML digest when high importance targets fail or
+// recover. Changes arriving within the batch window (30 seconds unless set with
+// WithBatchWindow) are sent as a single message.
+type Email struct {
+	config     EmailConfig
+	options    options
+	html       *htmltemplate.Template
+	text       *texttemplate.Template
+	dispatcher *dispatcher
+}
+
+// emailData contains the data rendered in the email templates.
+type emailData struct {
+	Subject    string
+	Title      string
+	Source     string
+	Time       time.Time
+	Conclusion status.Conclusion
+	Color      string
+	Groups     []eventGroup
+}
+
+// NewEmail creates an email notifier. Call Close to deliver pending
+// notifications and stop the background worker.
+func NewEmail(config EmailConfig, opts ...Option) *Email {
+	o := defaultOptions()
+	o.window = defaultEmailBatchWindow
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	funcs := map[string]any{"transition": transition}
+	e := &Email{
+		config:  config,
+		options: o,
+		html:    htmltemplate.Must(htmltemplate.New("email").Funcs(funcs).Parse(emailHTMLTemplateContent)),
+		text:    texttemplate.Must(texttemplate.New("email").Funcs(funcs).Parse(emailTextTemplateContent)),
+	}
+	e.dispatcher = newDispatcher("email", o, e.Send)
+
+	return e
+}
+
+// Notify queues the event for delivery when a high importance target fails or
+// recovers. Its signature matches status.HealthChecker.OnStatusChange.
+func (e *Email) Notify(ctx context.Context, event status.StatusChangeEvent) {
+	if event.Target.Importance == status.TargetImportanceLow {
+		return
+	}
+	if event.Current != status.HealthTargetStatusFail && event.Previous != status.HealthTargetStatusFail {
+		return
+	}
+
+	e.dispatcher.enqueue(ctx, event)
+}
+
+// Send delivers the events as a single digest synchronously, retrying with backoff on
+// failure. Permanent failures, such as rejected credentials or 5xx replies, are not retried.
+func (e *Email) Send(ctx context.Context, events []status.StatusChangeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	message, err := e.render(events)
+	if err != nil {
+		return err
+	}
+
+	return retry(ctx, e.options, func() (bool, error) {
+		return e.sendMail(ctx, message)
+	})
+}
+
+// Close delivers pending notifications and stops the background worker.
+func (e *Email) Close() {
+	e.dispatcher.close()
+}
+
+// render builds the MIME message containing the plain-text and HTML digest.
+func (e *Email) render(events []status.StatusChangeEvent) ([]byte, error) {
+	conclusion := events[len(events)-1].Conclusion
+	data := emailData{
+		Subject:    emailSubject(e.options.title, conclusion, events),
+		Title:      e.options.title,
+		Source:     e.options.source,
+		Time:       events[len(events)-1].Time,
+		Conclusion: conclusion,
+		Color:      slackColor(conclusion),
+		Groups:     groupEvents(events),
+	}
+
+	var text, html bytes.Buffer
+	if err := e.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render text email: %w", err)
+	}
+	if err := e.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render html email: %w", err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email part: %w", err)
+		}
+		if _, err := writer.Write(part.content); err != nil {
+			return nil, fmt.Errorf("failed to write email part: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close email parts: %w", err)
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", e.config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(e.config.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", data.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+// sendMail delivers the message through the configured SMTP server. It reports
+// whether a failed delivery may be retried.
+func (e *Email) sendMail(ctx context.Context, message []byte) (bool, error) {
+	client, retryable, err := e.dial(ctx)
+	if err != nil {
+		return retryable, err
+	}
+	defer client.Close()
+
+	return e.deliver(client, message)
+}
+
+// dial connects to the SMTP server, upgrades the session to TLS and authenticates.
+// It reports whether a failure may be retried.
+func (e *Email) dial(ctx context.Context) (*smtp.Client, bool, error) {
+	host, _, err := net.SplitHostPort(e.config.Addr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid smtp address %q: %w", e.config.Addr, err)
+	}
+
+	dialer := net.Dialer{Timeout: e.config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.config.Addr)
+	if err != nil {
+		return nil, !errors.Is(err, context.Canceled), fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(e.config.Timeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+
+		return nil, true, fmt.Errorf("failed to set deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+
+		return nil, retryableSMTP(err), fmt.Errorf("failed to start smtp session: %w", err)
+	}
+
+	if err := e.startTLS(client, host); err != nil {
+		client.Close()
+
+		return nil, false, err
+	}
+
+	if e.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.config.Username, e.config.Password, host)); err != nil {
+			client.Close()
+
+			return nil, false, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	return client, false, nil
+}
+
+// deliver sends the envelope and the message over an established session. It
+// reports whether a failure may be retried.
+func (e *Email) deliver(client *smtp.Client, message []byte) (bool, error) {
+	if err := client.Mail(e.config.From); err != nil {
+		return retryableSMTP(err), fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, to := range e.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return retryableSMTP(err), fmt.Errorf("failed to add recipient %s: %w", to, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return retryableSMTP(err), fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		return true, fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return retryableSMTP(err), fmt.Errorf("failed to send message: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return false, fmt.Errorf("failed to close smtp session: %w", err)
+	}
+
+	return false, nil
+}
+
+// retryableSMTP reports whether a failed SMTP command may succeed when retried.
+// Replies with a 5xx code are permanent failures.
+func retryableSMTP(err error) bool {
+	var reply *textproto.Error
+
+	return !errors.As(err, &reply) || reply.Code < smtpPermanentFailure
+}
+
+// startTLS upgrades the session to TLS when the server offers STARTTLS.
+func (e *Email) startTLS(client *smtp.Client, host string) error {
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		if e.config.RequireStartTLS {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+
+		return nil
+	}
+
+	config := e.config.TLSConfig
+	if config == nil {
+		config = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	}
+
+	if err := client.StartTLS(config); err != nil {
+		return fmt.Errorf("failed to start tls: %w", err)
+	}
+
+	return nil
+}
+
+// emailSubject summarises the digest, such as "System Status: Fail (1 failed, 2 recovered)".
+func emailSubject(title string, conclusion status.Conclusion, events []status.StatusChangeEvent) string {
+	failed, recovered := 0, 0
+	for _, event := range events {
+		if event.Current == status.HealthTargetStatusFail {
+			failed++
+		} else {
+			recovered++
+		}
+	}
+
+	return fmt.Sprintf("%s: %s (%d failed, %d recovered)", title, conclusion, failed, recovered)
+}
@@ -0,0 +1,293 @@
+package notify
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+// smtpMessage is a message received by the fake SMTP server.
+type smtpMessage struct {
+	auth       string
+	from       string
+	recipients []string
+	data       string
+}
+
+// fakeSMTPServer accepts SMTP sessions offering AUTH PLAIN and records the received messages.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []smtpMessage
+	sessions int
+	// rejectAuth makes the server reject credentials with a permanent failure.
+	rejectAuth bool
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeSMTPServer{listener: listener}
+	go s.serve()
+
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+func (s *fakeSMTPServer) session(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	var message smtpMessage
+
+	s.mu.Lock()
+	s.sessions++
+	rejectAuth := s.rejectAuth
+	s.mu.Unlock()
+
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			if rejectAuth {
+				text.PrintfLine("535 Authentication credentials invalid")
+
+				continue
+			}
+			message.auth = strings.TrimPrefix(line, "AUTH PLAIN ")
+			text.PrintfLine("235 Authentication successful")
+		case "MAIL":
+			message.from = line
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			message.recipients = append(message.recipients, line)
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 Go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			message.data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+
+			return
+		default:
+			text.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func TestEmail_SendsDigestOfHighImportanceTransitions(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeSMTPServer(t)
+
+	email := NewEmail(EmailConfig{
+		Addr:     server.listener.Addr().String(),
+		From:     "status@example.com",
+		To:       []string{"oncall@example.com", "team@example.com"},
+		Username: "user",
+		Password: "secret",
+	}, WithBatchWindow(50*time.Millisecond), WithTitle("Orders"), WithSource("web-1"))
+
+	events := []status.StatusChangeEvent{
+		{
+			Target:       status.HealthTarget{Name: "postgres", Group: "Infra"},
+			Previous:     status.HealthTargetStatusOk,
+			Current:      status.HealthTargetStatusFail,
+			ErrorMessage: "connection refused",
+			Conclusion:   status.ConclusionFail,
+		},
+		{
+			Target:     status.HealthTarget{Name: "redis", Group: "Infra"},
+			Previous:   status.HealthTargetStatusFail,
+			Current:    status.HealthTargetStatusOk,
+			Conclusion: status.ConclusionFail,
+		},
+		{
+			Target:     status.HealthTarget{Name: "cdn", Importance: status.TargetImportanceLow},
+			Previous:   status.HealthTargetStatusOk,
+			Current:    status.HealthTargetStatusFail,
+			Conclusion: status.ConclusionFail,
+		},
+		{
+			Target:     status.HealthTarget{Name: "payments"},
+			Previous:   status.HealthTargetStatusOk,
+			Current:    status.HealthTargetStatusDegraded,
+			Conclusion: status.ConclusionFail,
+		},
+	}
+	for _, event := range events {
+		email.Notify(context.Background(), event)
+	}
+	email.Close()
+
+	messages := server.received()
+	if len(messages) != 1 {
+		t.Fatalf("expected a single digest, got %d messages", len(messages))
+	}
+
+	received := messages[0]
+	auth, err := base64.StdEncoding.DecodeString(received.auth)
+	if err != nil || string(auth) != "\x00user\x00secret" {
+		t.Fatalf("unexpected credentials %q", auth)
+	}
+	if received.from != "MAIL FROM:<status@example.com>" {
+		t.Fatalf("unexpected sender %q", received.from)
+	}
+	if len(received.recipients) != 2 || received.recipients[1] != "RCPT TO:<team@example.com>" {
+		t.Fatalf("unexpected recipients %v", received.recipients)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(received.data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("decode subject: %v", err)
+	}
+	if subject != "Orders: Fail (1 failed, 1 recovered)" {
+		t.Fatalf("unexpected subject %q", subject)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q", msg.Header.Get("Content-Type"))
+	}
+
+	parts := make(map[string]string)
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		parts[part.Header.Get("Content-Type")] = string(body)
+	}
+
+	text := parts["text/plain; charset=utf-8"]
+	if !strings.Contains(text, "- postgres: ok → fail (connection refused)") ||
+		!strings.Contains(text, "- redis: fail → ok") {
+		t.Fatalf("unexpected text part %q", text)
+	}
+	if strings.Contains(text, "cdn") || strings.Contains(text, "payments") {
+		t.Fatalf("expected low importance and degraded transitions to be filtered, got %q", text)
+	}
+
+	html := parts["text/html; charset=utf-8"]
+	if !strings.Contains(html, "<h3") || !strings.Contains(html, "postgres") || !strings.Contains(html, "web-1") {
+		t.Fatalf("unexpected html part %q", html)
+	}
+}
+
+func TestEmail_RequireStartTLS(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeSMTPServer(t)
+
+	email := NewEmail(EmailConfig{
+		Addr:            server.listener.Addr().String(),
+		From:            "status@example.com",
+		To:              []string{"oncall@example.com"},
+		RequireStartTLS: true,
+	}, WithRetries(1, time.Millisecond))
+	defer email.Close()
+
+	err := email.Send(context.Background(), []status.StatusChangeEvent{{
+		Target:  status.HealthTarget{Name: "postgres"},
+		Current: status.HealthTargetStatusFail,
+	}})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("expected STARTTLS error, got %v", err)
+	}
+	if len(server.received()) != 0 {
+		t.Fatal("expected no message to be delivered")
+	}
+}
+
+func TestEmail_DoesNotRetryPermanentFailures(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeSMTPServer(t)
+	server.mu.Lock()
+	server.rejectAuth = true
+	server.mu.Unlock()
+
+	email := NewEmail(EmailConfig{
+		Addr:     server.listener.Addr().String(),
+		From:     "status@example.com",
+		To:       []string{"oncall@example.com"},
+		Username: "status",
+		Password: "wrong",
+	}, WithRetries(3, time.Millisecond), WithHTTPClient(&http.Client{}))
+	defer email.Close()
+
+	err := email.Send(context.Background(), []status.StatusChangeEvent{{
+		Target:  status.HealthTarget{Name: "postgres"},
+		Current: status.HealthTargetStatusFail,
+	}})
+	if err == nil || !strings.Contains(err.Error(), "535") {
+		t.Fatalf("expected the authentication failure, got %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if server.sessions != 1 {
+		t.Fatalf("expected a single attempt for a permanent failure, got %d", server.sessions)
+	}
+}
@@ -0,0 +1,364 @@
+// Package notify sends notifications about status changes reported by a
+// status.HealthChecker. Notifiers are registered as hooks:
+//
+//	webhook := notify.NewWebhook("https://example.com/hooks/status", secret)
+//	defer webhook.Close()
+//
+//	healthChecker.OnStatusChange(webhook.Notify)
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultAttempts    = 3
+	defaultBackoff     = time.Second
+	defaultQueueSize   = 100
+	backoffMultiplier  = 2
+	maxErrorBodyLength = 512
+	contentTypeJSON    = "application/json"
+	headerContentType  = "Content-Type"
+	defaultTitle       = "System Status"
+)
+
+// Option is a function that configures a notifier.
+type Option func(*options)
+
+// options holds the configuration shared by all notifiers.
+type options struct {
+	client    *http.Client
+	attempts  int
+	backoff   time.Duration
+	queueSize int
+	window    time.Duration
+	endpoint  string
+	title     string
+	source    string
+	serviceID string
+	logger    *slog.Logger
+}
+
+func defaultOptions() options {
+	return options{
+		client:    &http.Client{Timeout: defaultTimeout},
+		attempts:  defaultAttempts,
+		backoff:   defaultBackoff,
+		queueSize: defaultQueueSize,
+		title:     defaultTitle,
+		source:    defaultSource(),
+		logger:    slog.Default(),
+	}
+}
+
+func defaultSource() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "status"
+	}
+
+	return hostname
+}
+
+// WithHTTPClient sets the HTTP client used to deliver notifications.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithRetries sets the number of delivery attempts and the delay before the
+// first retry. The delay doubles after every failed attempt.
+func WithRetries(attempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.attempts = attempts
+		o.backoff = backoff
+	}
+}
+
+// WithBatchWindow makes the notifier collect status changes for the given
+// duration and deliver them as a single message. Not every notifier batches.
+func WithBatchWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.window = window
+	}
+}
+
+// WithEndpoint overrides the URL notifications are delivered to, for example
+// to use a local stand-in of a third-party API.
+func WithEndpoint(url string) Option {
+	return func(o *options) {
+		o.endpoint = url
+	}
+}
+
+// WithTitle sets the title of notification messages.
+func WithTitle(title string) Option {
+	return func(o *options) {
+		o.title = title
+	}
+}
+
+// WithSource sets the name of the system sending notifications. Defaults to the host name.
+func WithSource(source string) Option {
+	return func(o *options) {
+		o.source = source
+	}
+}
+
+// WithServiceID sets the identifier of the monitored service, shared by all of
+// its replicas. PagerDuty uses it in deduplication keys, so that a change reported
+// by any replica updates the same incident.
+func WithServiceID(id string) Option {
+	return func(o *options) {
+		o.serviceID = id
+	}
+}
+
+// WithLogger sets the logger reporting dropped and undelivered notifications.
+// Defaults to slog.Default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithQueueSize sets the number of notifications waiting for delivery. When the
+// queue is full, new notifications are dropped.
+func WithQueueSize(size int) Option {
+	return func(o *options) {
+		o.queueSize = size
+	}
+}
+
+// dispatcher delivers events in order on a background goroutine, so that
+// status hooks never block on network calls. Events arriving within the batch
+// window of the first one are delivered together.
+type dispatcher struct {
+	name    string
+	window  time.Duration
+	logger  *slog.Logger
+	events  chan queuedEvent
+	deliver func(ctx context.Context, events []status.StatusChangeEvent) error
+	wg      sync.WaitGroup
+
+	// mu guards sending to events against closing it.
+	mu     sync.Mutex
+	closed bool
+}
+
+// queuedEvent is an event waiting for delivery along with the hook context.
+type queuedEvent struct {
+	ctx   context.Context
+	event status.StatusChangeEvent
+}
+
+func newDispatcher(
+	name string,
+	o options,
+	deliver func(ctx context.Context, events []status.StatusChangeEvent) error,
+) *dispatcher {
+	queueSize := o.queueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	d := &dispatcher{
+		name:    name,
+		window:  o.window,
+		logger:  logger,
+		events:  make(chan queuedEvent, queueSize),
+		deliver: deliver,
+	}
+
+	d.wg.Add(1)
+	go d.loop()
+
+	return d
+}
+
+// enqueue queues the event for delivery or drops it when the queue is full or
+// the notifier is closed.
+func (d *dispatcher) enqueue(ctx context.Context, event status.StatusChangeEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		d.logger.Warn("dropping status notification, notifier is closed",
+			"notifier", d.name, "target", event.Target.Name)
+
+		return
+	}
+
+	select {
+	case d.events <- queuedEvent{ctx: context.WithoutCancel(ctx), event: event}:
+	default:
+		d.logger.Error("dropping status notification, queue is full",
+			"notifier", d.name, "target", event.Target.Name)
+	}
+}
+
+func (d *dispatcher) loop() {
+	defer d.wg.Done()
+
+	for queued := range d.events {
+		events := append([]status.StatusChangeEvent{queued.event}, d.collect()...)
+
+		if err := d.deliver(queued.ctx, events); err != nil {
+			d.logger.Error("failed to deliver status notification",
+				"notifier", d.name, "events", len(events), "error", err)
+		}
+	}
+}
+
+// collect returns the events queued within the batch window.
+func (d *dispatcher) collect() []status.StatusChangeEvent {
+	if d.window <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d.window)
+	defer timer.Stop()
+
+	var events []status.StatusChangeEvent
+	for {
+		select {
+		case queued, ok := <-d.events:
+			if !ok {
+				return events
+			}
+			events = append(events, queued.event)
+		case <-timer.C:
+			return events
+		}
+	}
+}
+
+// close stops accepting events and waits until queued events are delivered.
+func (d *dispatcher) close() {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.events)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+// eventGroup holds the status changes of targets sharing a group.
+type eventGroup struct {
+	Name   string
+	Events []status.StatusChangeEvent
+}
+
+// groupEvents groups events by target group. Ungrouped events come first,
+// followed by groups in alphabetical order.
+func groupEvents(events []status.StatusChangeEvent) []eventGroup {
+	var groups []eventGroup
+	index := make(map[string]int)
+
+	for _, event := range events {
+		i, ok := index[event.Target.Group]
+		if !ok {
+			i = len(groups)
+			index[event.Target.Group] = i
+			groups = append(groups, eventGroup{Name: event.Target.Group})
+		}
+		groups[i].Events = append(groups[i].Events, event)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups
+}
+
+// post sends body to url, retrying with exponential backoff on network errors,
+// 429 and 5xx responses.
+func post(ctx context.Context, o options, url string, body []byte, header http.Header) error {
+	return retry(ctx, o, func() (bool, error) {
+		return postOnce(ctx, o.client, url, body, header)
+	})
+}
+
+// retry calls attempt until it succeeds, reports that the failure is permanent
+// or the configured number of attempts is exhausted. The delay between attempts
+// grows exponentially.
+func retry(ctx context.Context, o options, attempt func() (bool, error)) error {
+	backoff := o.backoff
+	attempts := max(o.attempts, 1)
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("waiting to retry: %w", ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= backoffMultiplier
+		}
+
+		retryable, err := attempt()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if !retryable {
+			break
+		}
+	}
+
+	return lastErr
+}
+
+// postOnce performs a single POST request. It reports whether a failed request may be retried.
+func postOnce(ctx context.Context, client *http.Client, url string, body []byte, header http.Header) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if req.Header.Get(headerContentType) == "" {
+		req.Header.Set(headerContentType, contentTypeJSON)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return !errors.Is(err, context.Canceled), fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return false, nil
+	}
+
+	text, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+	return retry, fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, bytes.TrimSpace(text))
+}
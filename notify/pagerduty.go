@@ -0,0 +1,157 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+// defaultPagerDutyEndpoint is the PagerDuty Events API v2 enqueue URL.
+const defaultPagerDutyEndpoint = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty event actions and severities.
+const (
+	pagerDutyTrigger  = "trigger"
+	pagerDutyResolve  = "resolve"
+	pagerDutyCritical = "critical"
+	pagerDutyWarning  = "warning"
+)
+
+// pagerDutyEvent is the body of a PagerDuty Events API v2 request.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Component     string         `json:"component"`
+	Group         string         `json:"group,omitempty"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// PagerDuty triggers and resolves PagerDuty incidents through the Events API v2.
+// A failed or degraded target triggers an incident and a healthy one resolves it.
+// Every target uses a deduplication key built from its group and name and the
+// service ID set with WithServiceID, so repeated triggers from any replica update
+// the same incident. Severity is critical for high importance targets and warning
+// for low importance or degraded ones.
+type PagerDuty struct {
+	routingKey string
+	options    options
+	dispatcher *dispatcher
+}
+
+// NewPagerDuty creates a PagerDuty notifier for the integration routing key.
+// Call Close to deliver pending notifications and stop the background worker.
+func NewPagerDuty(routingKey string, opts ...Option) *PagerDuty {
+	o := defaultOptions()
+	o.endpoint = defaultPagerDutyEndpoint
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := &PagerDuty{
+		routingKey: routingKey,
+		options:    o,
+	}
+	p.dispatcher = newDispatcher("pagerduty", o, p.sendAll)
+
+	return p
+}
+
+// Notify queues the event for delivery. Its signature matches
+// status.HealthChecker.OnStatusChange.
+func (p *PagerDuty) Notify(ctx context.Context, event status.StatusChangeEvent) {
+	p.dispatcher.enqueue(ctx, event)
+}
+
+// Send delivers the event synchronously, retrying with backoff on failure.
+func (p *PagerDuty) Send(ctx context.Context, event status.StatusChangeEvent) error {
+	body, err := json.Marshal(p.newEvent(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal pagerduty event: %w", err)
+	}
+
+	return post(ctx, p.options, p.options.endpoint, body, nil)
+}
+
+// Close delivers pending notifications and stops the background worker.
+func (p *PagerDuty) Close() {
+	p.dispatcher.close()
+}
+
+// sendAll delivers the events one by one.
+func (p *PagerDuty) sendAll(ctx context.Context, events []status.StatusChangeEvent) error {
+	var errs []error
+	for _, event := range events {
+		if err := p.Send(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *PagerDuty) newEvent(event status.StatusChangeEvent) pagerDutyEvent {
+	dedupKey := p.dedupKey(event.Target)
+
+	if event.Current == status.HealthTargetStatusOk {
+		return pagerDutyEvent{
+			RoutingKey:  p.routingKey,
+			EventAction: pagerDutyResolve,
+			DedupKey:    dedupKey,
+		}
+	}
+
+	summary := fmt.Sprintf("%s is %s", event.Target.Name, event.Current)
+	if event.ErrorMessage != "" {
+		summary += ": " + event.ErrorMessage
+	}
+
+	return pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: pagerDutyTrigger,
+		DedupKey:    dedupKey,
+		Payload: &pagerDutyPayload{
+			Summary:   summary,
+			Source:    p.options.source,
+			Severity:  pagerDutySeverity(event),
+			Timestamp: event.Time,
+			Component: event.Target.Name,
+			Group:     event.Target.Group,
+			CustomDetails: map[string]any{
+				"previous_status": event.Previous,
+				"status":          event.Current,
+				"importance":      event.Target.Importance,
+				"duration":        event.Duration.String(),
+				"conclusion":      event.Conclusion,
+			},
+		},
+	}
+}
+
+// dedupKey returns the deduplication key of a target. It does not depend on the
+// source, which defaults to the host name and changes when a pod is replaced.
+func (p *PagerDuty) dedupKey(target status.HealthTarget) string {
+	return fmt.Sprintf("status/%s/%s/%s", p.options.serviceID, target.Group, target.Name)
+}
+
+// pagerDutySeverity derives the incident severity from the target importance and status.
+func pagerDutySeverity(event status.StatusChangeEvent) string {
+	if event.Current == status.HealthTargetStatusDegraded ||
+		event.Target.Importance == status.TargetImportanceLow {
+		return pagerDutyWarning
+	}
+
+	return pagerDutyCritical
+}
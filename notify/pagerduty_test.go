@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alarmistdev/status"
+)
+
+func TestPagerDuty_TriggersAndResolves(t *testing.T) {
+	t.Parallel()
+
+	var events []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pagerDuty := NewPagerDuty("routing-key", WithEndpoint(server.URL), WithSource("orders-1"))
+	defer pagerDuty.Close()
+
+	target := status.HealthTarget{Name: "postgres", Group: "Infra", Importance: status.TargetImportanceHigh}
+	transitions := []status.StatusChangeEvent{
+		{
+			Target:       target,
+			Previous:     status.HealthTargetStatusOk,
+			Current:      status.HealthTargetStatusFail,
+			ErrorMessage: "refused",
+		},
+		{Target: target, Previous: status.HealthTargetStatusFail, Current: status.HealthTargetStatusOk},
+	}
+	for _, transition := range transitions {
+		if err := pagerDuty.Send(context.Background(), transition); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	trigger, resolve := events[0], events[1]
+	if trigger.EventAction != "trigger" || trigger.RoutingKey != "routing-key" || trigger.Payload == nil ||
+		trigger.Payload.Severity != "critical" || trigger.Payload.Summary != "postgres is fail: refused" ||
+		trigger.Payload.Source != "orders-1" {
+		t.Fatalf("unexpected trigger event: %+v %+v", trigger, trigger.Payload)
+	}
+	if resolve.EventAction != "resolve" || resolve.Payload != nil {
+		t.Fatalf("unexpected resolve event: %+v", resolve)
+	}
+	if trigger.DedupKey == "" || trigger.DedupKey != resolve.DedupKey {
+		t.Fatalf("expected stable dedup key, got %q and %q", trigger.DedupKey, resolve.DedupKey)
+	}
+}
+
+func TestPagerDuty_DedupKeyIgnoresSource(t *testing.T) {
+	t.Parallel()
+
+	target := status.HealthTarget{Name: "postgres", Group: "Infra"}
+	previous := NewPagerDuty("routing-key", WithSource("orders-7d9f-abc"), WithServiceID("orders"))
+	defer previous.Close()
+	next := NewPagerDuty("routing-key", WithSource("orders-5c4b-xyz"), WithServiceID("orders"))
+	defer next.Close()
+
+	if previous.dedupKey(target) != next.dedupKey(target) {
+		t.Fatalf("expected replicas to share the dedup key, got %q and %q",
+			previous.dedupKey(target), next.dedupKey(target))
+	}
+
+	other := NewPagerDuty("routing-key", WithServiceID("billing"))
+	defer other.Close()
+
+	if other.dedupKey(target) == next.dedupKey(target) {
+		t.Fatalf("expected services to use different dedup keys, got %q", other.dedupKey(target))
+	}
+}
+
+func TestPagerDutySeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		event    status.StatusChangeEvent
+		expected string
+	}{
+		{
+			event: status.StatusChangeEvent{
+				Target:  status.HealthTarget{Importance: status.TargetImportanceHigh},
+				Current: status.HealthTargetStatusFail,
+			},
+			expected: "critical",
+		},
+		{
+			event: status.StatusChangeEvent{
+				Target:  status.HealthTarget{Importance: status.TargetImportanceLow},
+				Current: status.HealthTargetStatusFail,
+			},
+			expected: "warning",
+		},
+		{
+			event: status.StatusChangeEvent{
+				Target:  status.HealthTarget{Importance: status.TargetImportanceHigh},
+				Current: status.HealthTargetStatusDegraded,
+			},
+			expected: "warning",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := pagerDutySeverity(tt.event); got != tt.expected {
+			t.Errorf("expected severity %s, got %s", tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+const (
+	// defaultSlackBatchWindow is how long Slack collects status changes into one message.
+	defaultSlackBatchWindow = 5 * time.Second
+
+	slackColorOk      = "#2e7d32"
+	slackColorWarning = "#f57c00"
+	slackColorFail    = "#c62828"
+)
+
+// slackMessage is the body of a Slack incoming webhook request.
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Slack posts status changes to a Slack incoming webhook. Changes arriving within
+// the batch window (5 seconds unless set with WithBatchWindow) are sent as one
+// message, grouped by target group and coloured by the overall conclusion.
+type Slack struct {
+	url        string
+	options    options
+	dispatcher *dispatcher
+}
+
+// NewSlack creates a Slack notifier posting to the incoming webhook URL. Call
+// Close to deliver pending notifications and stop the background worker.
+func NewSlack(webhookURL string, opts ...Option) *Slack {
+	o := defaultOptions()
+	o.window = defaultSlackBatchWindow
+	o.endpoint = webhookURL
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &Slack{
+		url:     o.endpoint,
+		options: o,
+	}
+	s.dispatcher = newDispatcher("slack", o, s.Send)
+
+	return s
+}
+
+// Notify queues the event for delivery. Its signature matches
+// status.HealthChecker.OnStatusChange.
+func (s *Slack) Notify(ctx context.Context, event status.StatusChangeEvent) {
+	s.dispatcher.enqueue(ctx, event)
+}
+
+// Send delivers the events as a single message synchronously.
+func (s *Slack) Send(ctx context.Context, events []status.StatusChangeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(newSlackMessage(s.options.title, events))
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack message: %w", err)
+	}
+
+	return post(ctx, s.options, s.url, body, nil)
+}
+
+// Close delivers pending notifications and stops the background worker.
+func (s *Slack) Close() {
+	s.dispatcher.close()
+}
+
+func newSlackMessage(title string, events []status.StatusChangeEvent) slackMessage {
+	conclusion := events[len(events)-1].Conclusion
+	headline := fmt.Sprintf("%s: %s", title, conclusion)
+
+	blocks := []slackBlock{{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: headline},
+	}}
+
+	for _, group := range groupEvents(events) {
+		var text strings.Builder
+		if group.Name != "" {
+			fmt.Fprintf(&text, "*%s*\n", escapeSlack(group.Name))
+		}
+		for _, event := range group.Events {
+			fmt.Fprintf(&text, "• *%s*: %s", escapeSlack(event.Target.Name), transition(event))
+			if event.ErrorMessage != "" {
+				fmt.Fprintf(&text, " — %s", escapeSlack(event.ErrorMessage))
+			}
+			text.WriteString("\n")
+		}
+
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: strings.TrimSuffix(text.String(), "\n")},
+		})
+	}
+
+	return slackMessage{
+		Text: headline,
+		Attachments: []slackAttachment{{
+			Color:  slackColor(conclusion),
+			Blocks: blocks,
+		}},
+	}
+}
+
+// slackColor returns the attachment colour of a conclusion, matching the status page.
+func slackColor(conclusion status.Conclusion) string {
+	switch conclusion {
+	case status.ConclusionOk:
+		return slackColorOk
+	case status.ConclusionWarning:
+		return slackColorWarning
+	default:
+		return slackColorFail
+	}
+}
+
+// escapeSlack escapes the control characters of Slack mrkdwn.
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// transition describes the status change of an event, such as "ok → fail".
+func transition(event status.StatusChangeEvent) string {
+	if event.Previous == "" {
+		return string(event.Current)
+	}
+
+	return fmt.Sprintf("%s → %s", event.Previous, event.Current)
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+func TestSlack_BatchesTransitionsByGroup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		messages []slackMessage
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message slackMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("decode message: %v", err)
+		}
+
+		mu.Lock()
+		messages = append(messages, message)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	slack := NewSlack(server.URL, WithBatchWindow(50*time.Millisecond), WithTitle("Orders"))
+
+	events := []status.StatusChangeEvent{
+		{
+			Target:     status.HealthTarget{Name: "postgres", Group: "Infra"},
+			Previous:   status.HealthTargetStatusOk,
+			Current:    status.HealthTargetStatusFail,
+			Conclusion: status.ConclusionFail,
+		},
+		{
+			Target:       status.HealthTarget{Name: "payments", Group: "External"},
+			Previous:     status.HealthTargetStatusOk,
+			Current:      status.HealthTargetStatusDegraded,
+			ErrorMessage: "latency <500ms>",
+			Conclusion:   status.ConclusionFail,
+		},
+	}
+	for _, event := range events {
+		slack.Notify(context.Background(), event)
+	}
+	slack.Close()
+
+	if len(messages) != 1 {
+		t.Fatalf("expected a single batched message, got %d", len(messages))
+	}
+
+	message := messages[0]
+	if message.Text != "Orders: Fail" {
+		t.Fatalf("unexpected text %q", message.Text)
+	}
+	if len(message.Attachments) != 1 || message.Attachments[0].Color != slackColorFail {
+		t.Fatalf("unexpected attachments: %+v", message.Attachments)
+	}
+
+	blocks := message.Attachments[0].Blocks
+	if len(blocks) != 3 || blocks[0].Type != "header" {
+		t.Fatalf("expected header and two group sections, got %+v", blocks)
+	}
+	if !strings.HasPrefix(blocks[1].Text.Text, "*External*\n• *payments*: ok → degraded — latency &lt;500ms&gt;") {
+		t.Fatalf("unexpected first group section %q", blocks[1].Text.Text)
+	}
+	if blocks[2].Text.Text != "*Infra*\n• *postgres*: ok → fail" {
+		t.Fatalf("unexpected second group section %q", blocks[2].Text.Text)
+	}
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alarmistdev/status"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 signature of the webhook payload.
+	SignatureHeader = "X-Status-Signature"
+	// signaturePrefix precedes the hex encoded signature in SignatureHeader.
+	signaturePrefix = "sha256="
+)
+
+// WebhookPayload is the JSON body posted by Webhook.
+type WebhookPayload struct {
+	Target     status.HealthTarget       `json:"target"`
+	Previous   status.HealthTargetStatus `json:"previous_status,omitempty"`
+	Status     status.HealthTargetStatus `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	Duration   time.Duration             `json:"duration"`
+	Conclusion status.Conclusion         `json:"conclusion"`
+	Time       time.Time                 `json:"time"`
+}
+
+// Webhook posts a signed JSON payload to a URL whenever a target changes status.
+// The payload is signed with HMAC-SHA256 over a shared secret and the signature
+// is sent in SignatureHeader as "sha256=<hex>". See VerifySignature.
+type Webhook struct {
+	url        string
+	secret     []byte
+	options    options
+	dispatcher *dispatcher
+}
+
+// NewWebhook creates a webhook notifier posting one payload per status change
+// to url. Call Close to deliver pending notifications and stop the background worker.
+func NewWebhook(url, secret string, opts ...Option) *Webhook {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	w := &Webhook{
+		url:     url,
+		secret:  []byte(secret),
+		options: o,
+	}
+	w.dispatcher = newDispatcher("webhook", o, w.sendAll)
+
+	return w
+}
+
+// Notify queues the event for delivery. Its signature matches
+// status.HealthChecker.OnStatusChange.
+func (w *Webhook) Notify(ctx context.Context, event status.StatusChangeEvent) {
+	w.dispatcher.enqueue(ctx, event)
+}
+
+// Send delivers the event synchronously, retrying with backoff on failure.
+func (w *Webhook) Send(ctx context.Context, event status.StatusChangeEvent) error {
+	body, err := json.Marshal(newWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set(SignatureHeader, Sign(w.secret, body))
+
+	return post(ctx, w.options, w.url, body, header)
+}
+
+// sendAll delivers the events one by one.
+func (w *Webhook) sendAll(ctx context.Context, events []status.StatusChangeEvent) error {
+	var errs []error
+	for _, event := range events {
+		if err := w.Send(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close delivers pending notifications and stops the background worker.
+func (w *Webhook) Close() {
+	w.dispatcher.close()
+}
+
+// Sign returns the value of SignatureHeader for the given payload.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is a valid SignatureHeader value for the payload.
+func VerifySignature(secret, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+func newWebhookPayload(event status.StatusChangeEvent) WebhookPayload {
+	return WebhookPayload{
+		Target:     event.Target,
+		Previous:   event.Previous,
+		Status:     event.Current,
+		Error:      event.ErrorMessage,
+		Duration:   event.Duration,
+		Conclusion: event.Conclusion,
+		Time:       event.Time,
+	}
+}
@@ -0,0 +1,171 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status"
+	"github.com/alarmistdev/status/check"
+)
+
+func TestWebhook_SignsAndDeliversTransitions(t *testing.T) {
+	t.Parallel()
+
+	const secret = "s3cr3t"
+
+	var (
+		mu       sync.Mutex
+		payloads []WebhookPayload
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		if !VerifySignature([]byte(secret), body, r.Header.Get(SignatureHeader)) {
+			t.Errorf("invalid signature %q", r.Header.Get(SignatureHeader))
+		}
+
+		var payload WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, secret)
+
+	failing := true
+	checker := status.NewHealthChecker().
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			if failing {
+				return errors.New("connection refused")
+			}
+
+			return nil
+		}), status.WithGroup("Infra")).
+		OnStatusChange(webhook.Notify)
+
+	_, _ = checker.Check(context.Background())
+	failing = false
+	_, _ = checker.Check(context.Background())
+
+	webhook.Close()
+
+	if len(payloads) != 2 {
+		t.Fatalf("expected 2 payloads, got %d", len(payloads))
+	}
+
+	failed := payloads[0]
+	if failed.Target.Name != "postgres" || failed.Target.Group != "Infra" ||
+		failed.Status != status.HealthTargetStatusFail || failed.Error != "connection refused" ||
+		failed.Conclusion != status.ConclusionFail {
+		t.Fatalf("unexpected failure payload: %+v", failed)
+	}
+
+	recovered := payloads[1]
+	if recovered.Previous != status.HealthTargetStatusFail || recovered.Status != status.HealthTargetStatusOk ||
+		recovered.Conclusion != status.ConclusionOk {
+		t.Fatalf("unexpected recovery payload: %+v", recovered)
+	}
+}
+
+func TestWebhook_RetriesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, "secret", WithRetries(3, time.Millisecond))
+	defer webhook.Close()
+
+	if err := webhook.Send(context.Background(), status.StatusChangeEvent{}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestWebhook_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, "secret", WithRetries(3, time.Millisecond))
+	defer webhook.Close()
+
+	if err := webhook.Send(context.Background(), status.StatusChangeEvent{}); err == nil {
+		t.Fatal("expected error for bad request")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestWebhook_NotifyAfterClose(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	webhook := NewWebhook(server.URL, "secret", WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	webhook.Close()
+	webhook.Close()
+
+	webhook.Notify(context.Background(), status.StatusChangeEvent{Current: status.HealthTargetStatusFail})
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("expected notifications after close to be dropped, got %d deliveries", got)
+	}
+	if !strings.Contains(logs.String(), "notifier is closed") {
+		t.Fatalf("expected the dropped notification to be logged, got %q", logs.String())
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"status":"fail"}`)
+	signature := Sign([]byte("secret"), body)
+
+	if !VerifySignature([]byte("secret"), body, signature) {
+		t.Fatal("expected signature to verify")
+	}
+	if VerifySignature([]byte("other"), body, signature) {
+		t.Fatal("expected signature with another secret to be rejected")
+	}
+	if VerifySignature([]byte("secret"), []byte(`{}`), signature) {
+		t.Fatal("expected signature of another body to be rejected")
+	}
+}
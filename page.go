@@ -21,6 +21,8 @@ type Page struct {
 	hc          *HealthChecker
 	links       []Link
 	showVersion bool
+	authorize   Authorizer
+	challenge   string
 }
 
 // PageOption is a function that configures a Page.
@@ -64,6 +66,21 @@ func WithVersion(show bool) PageOption {
 	}
 }
 
+// WithPageAuthorizer restricts the details of check results on the status page to
+// requests accepted by authorize, overriding the Authorizer of the HealthChecker
+// (see WithAuthorizer). Other requests see the status of each target and the
+// conclusion only.
+//
+// When challenge is set, for example `Basic realm="status"` together with BasicAuth,
+// the page links to itself with the details query parameter, which responds with
+// the challenge in the WWW-Authenticate header so that the browser asks for credentials.
+func WithPageAuthorizer(authorize Authorizer, challenge string) PageOption {
+	return func(p *Page) {
+		p.authorize = authorize
+		p.challenge = challenge
+	}
+}
+
 // NewPage creates a new status page with the given options.
 func NewPage(opts ...PageOption) *Page {
 	p := &Page{
@@ -96,6 +113,9 @@ type PageData struct {
 	Title         string
 	Version       string
 	Conclusion    Conclusion
+	Draining      bool
+	Redacted      bool
+	SignIn        bool
 	HealthResults []HealthCheckResult
 	HealthGroups  []HealthGroup
 	Links         []Link
@@ -112,22 +132,47 @@ const (
 // Handler returns an HTTP handler that serves the status page.
 func (p *Page) Handler() http.HandlerFunc {
 	version := retrieveVersion()
+	authorize := p.authorizer()
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redacted := authorize != nil && !authorize(r)
+		signIn := redacted && p.challenge != ""
+		if _, details := r.URL.Query()["details"]; details && signIn {
+			w.Header().Set("WWW-Authenticate", p.challenge)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
 		var healthResults []HealthCheckResult
+		var draining bool
 		if p.hc != nil {
+			draining = p.hc.Draining()
 			var err error
 			healthResults, err = p.hc.Check(r.Context())
 			if err != nil {
+				if redacted {
+					http.Error(w, "Error checking health", http.StatusInternalServerError)
+
+					return
+				}
 				http.Error(w, fmt.Sprintf("Error checking health: %v", err), http.StatusInternalServerError)
 
 				return
 			}
 		}
 
+		conclusion := calculateConclusion(healthResults)
+		if redacted {
+			healthResults = redact(healthResults)
+		}
+
 		data := PageData{
 			Title:         p.title,
-			Conclusion:    calculateConclusion(healthResults),
+			Conclusion:    conclusion,
+			Draining:      draining,
+			Redacted:      redacted,
+			SignIn:        signIn,
 			HealthResults: getUngroupedResults(healthResults),
 			HealthGroups:  groupHealthResults(healthResults),
 			Links:         p.links,
@@ -144,6 +189,15 @@ func (p *Page) Handler() http.HandlerFunc {
 	})
 }
 
+// authorizer returns the Authorizer of the page, or the one of the HealthChecker when unset.
+func (p *Page) authorizer() Authorizer {
+	if p.authorize == nil && p.hc != nil {
+		return p.hc.authorize
+	}
+
+	return p.authorize
+}
+
 func retrieveVersion() string {
 	var version = "unknown"
 
@@ -205,7 +259,11 @@ func calculateConclusion(results []HealthCheckResult) Conclusion {
 	hasWarning := false
 
 	for _, result := range results {
-		if result.Status != HealthTargetStatusOk {
+		switch result.Status {
+		case HealthTargetStatusOk, HealthTargetStatusMaintenance:
+		case HealthTargetStatusDegraded:
+			hasWarning = true
+		default:
 			switch result.Target.Importance {
 			case TargetImportanceHigh:
 				hasFail = true
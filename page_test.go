@@ -87,6 +87,42 @@ func TestPage_Handler(t *testing.T) {
 				"Warning: cache miss",
 			},
 		},
+		{
+			name: "page with degraded high importance health check",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithTarget("Database", check.CheckFunc(func(ctx context.Context) error {
+						return check.Degraded(errors.New("high latency"))
+					}))),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<h1>Test Status / <span class="conclusion warning">Not Great, Not Terrible</span></h1>`,
+				`<div class="status-item warning">`,
+				"Status: <strong>degraded</strong>",
+				"Warning: high latency",
+			},
+		},
+		{
+			name: "page with result details",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithTarget("Disk", check.ResultCheckFunc(func(ctx context.Context) (check.Result, error) {
+						return check.Result{
+							Details:       map[string]any{"path": "/var"},
+							ObservedValue: 42.5,
+							Unit:          "GB",
+						}, nil
+					}))),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<p class="observed">Observed: 42.5 GB</p>`,
+				`<p class="detail">path: /var</p>`,
+			},
+		},
 		{
 			name: "page with multiple health checks",
 			page: NewPage(
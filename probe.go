@@ -0,0 +1,118 @@
+package status
+
+import (
+	"net/http"
+	"slices"
+)
+
+// Probe is a kind of Kubernetes probe a health check target participates in.
+type Probe string
+
+const (
+	// ProbeLiveness is used to decide whether the application must be restarted.
+	ProbeLiveness = Probe("liveness")
+	// ProbeReadiness is used to decide whether the application may receive traffic.
+	ProbeReadiness = Probe("readiness")
+	// ProbeStartup is used to decide whether the application has finished starting.
+	ProbeStartup = Probe("startup")
+)
+
+// WithProbes sets the probe kinds a health check target participates in.
+// By default targets participate in the readiness and startup probes only,
+// so that a failing dependency never causes a restart through the liveness probe.
+func WithProbes(probes ...Probe) TargetOption {
+	return func(t *HealthTarget) {
+		t.probes = probes
+	}
+}
+
+// LivenessHandler returns an HTTP handler for the Kubernetes liveness probe.
+// It checks the targets participating in ProbeLiveness.
+func (c *HealthChecker) LivenessHandler() http.HandlerFunc {
+	return c.probeHandler(ProbeLiveness)
+}
+
+// ReadinessHandler returns an HTTP handler for the Kubernetes readiness probe.
+// It checks the targets participating in ProbeReadiness, and responds with 503
+// without running any checks while the HealthChecker is draining (see Drain).
+func (c *HealthChecker) ReadinessHandler() http.HandlerFunc {
+	handler := c.probeHandler(ProbeReadiness)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.Draining() {
+			respondDraining(w)
+
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
+// StartupHandler returns an HTTP handler for the Kubernetes startup probe.
+// It checks the targets participating in ProbeStartup until all of them have
+// passed at once; afterwards it always responds with 200 without running any checks.
+func (c *HealthChecker) StartupHandler() http.HandlerFunc {
+	handler := c.probeHandler(ProbeStartup)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.startupPassed.Load() {
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
+// probeHandler returns an HTTP handler that checks the targets participating in probe.
+func (c *HealthChecker) probeHandler(probe Probe) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, err := c.check(r.Context(), probe)
+		if err != nil {
+			respondJSON(w, http.StatusInternalServerError, err)
+
+			return
+		}
+
+		if probe == ProbeStartup && allPassed(results) {
+			c.startupPassed.Store(true)
+		}
+
+		respondJSON(w, resultsStatusCode(results), c.visibleResults(r, results))
+	})
+}
+
+// participates reports whether the target participates in the given probe.
+// Every target participates in the empty probe.
+func (t HealthTarget) participates(probe Probe) bool {
+	return probe == "" || slices.Contains(t.probes, probe)
+}
+
+// allPassed reports whether none of the results failed.
+func allPassed(results []HealthCheckResult) bool {
+	for _, result := range results {
+		if result.Status == HealthTargetStatusFail {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterProbe returns the results of targets participating in the given probe.
+func filterProbe(results []HealthCheckResult, probe Probe) []HealthCheckResult {
+	if probe == "" {
+		return results
+	}
+
+	filtered := make([]HealthCheckResult, 0, len(results))
+	for _, result := range results {
+		if result.Target.participates(probe) {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
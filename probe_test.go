@@ -0,0 +1,89 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_ProbeHandlers(t *testing.T) {
+	t.Parallel()
+
+	var livenessRuns, dependencyRuns atomic.Int32
+	checker := NewHealthChecker().
+		WithTarget("event loop", check.CheckFunc(func(ctx context.Context) error {
+			livenessRuns.Add(1)
+
+			return nil
+		}), WithProbes(ProbeLiveness)).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			dependencyRuns.Add(1)
+
+			return errors.New("connection refused")
+		}))
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		expectedStatus int
+	}{
+		{name: "liveness", handler: checker.LivenessHandler(), expectedStatus: http.StatusOK},
+		{name: "readiness", handler: checker.ReadinessHandler(), expectedStatus: http.StatusInternalServerError},
+		{name: "startup", handler: checker.StartupHandler(), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		tt.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assertStatusCode(t, tt.expectedStatus, w.Code)
+	}
+
+	if got := livenessRuns.Load(); got != 1 {
+		t.Fatalf("expected liveness target to run once, ran %d times", got)
+	}
+	if got := dependencyRuns.Load(); got != 2 {
+		t.Fatalf("expected dependency target to run twice, ran %d times", got)
+	}
+}
+
+func TestHealthChecker_StartupHandler_Latches(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	var runs atomic.Int32
+	checker := NewHealthChecker().
+		WithTarget("migrations", check.CheckFunc(func(ctx context.Context) error {
+			runs.Add(1)
+			if failing.Load() {
+				return errors.New("pending")
+			}
+
+			return nil
+		}), WithProbes(ProbeStartup))
+
+	handler := checker.StartupHandler()
+	serve := func() int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		return w.Code
+	}
+
+	failing.Store(true)
+	assertStatusCode(t, http.StatusInternalServerError, serve())
+
+	failing.Store(false)
+	assertStatusCode(t, http.StatusOK, serve())
+
+	failing.Store(true)
+	assertStatusCode(t, http.StatusOK, serve())
+
+	if got := runs.Load(); got != 2 {
+		t.Fatalf("expected no runs after startup passed, got %d runs", got)
+	}
+}
@@ -0,0 +1,163 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCheckInterval is how often a target is checked in background mode
+	// unless overridden with WithInterval.
+	defaultCheckInterval = 30 * time.Second
+	// staleIntervalFactor defines after how many missed intervals a cached result is considered stale.
+	staleIntervalFactor = 2
+)
+
+// Start switches the HealthChecker into background mode. Every target is checked
+// once synchronously and then periodically on its own interval (see WithInterval)
+// until ctx is done or Stop is called. While running, Check and every handler built
+// on it serve the latest cached results instead of executing checks inline. Once
+// background mode ends, Check executes checks inline again.
+//
+// Targets must not be added after Start has been called.
+func (c *HealthChecker) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.mu.Unlock()
+
+		return errors.New("health checker already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	results, err := c.checkTargets(ctx, "")
+	if err != nil {
+		c.Stop()
+
+		return fmt.Errorf("initial health check: %w", err)
+	}
+
+	c.mu.Lock()
+	c.latest = results
+	c.running = true
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go c.run(ctx)
+
+	return nil
+}
+
+// run refreshes the targets in the background until ctx is done and then leaves
+// background mode, whether ctx was cancelled by Stop or by the caller of Start.
+func (c *HealthChecker) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	var loops sync.WaitGroup
+	for i := range c.targets {
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+
+			c.loop(ctx, i)
+		}()
+	}
+	loops.Wait()
+
+	c.reset()
+}
+
+// Stop terminates background mode and waits for in-flight checks to finish.
+// Afterwards Check executes checks inline again.
+func (c *HealthChecker) Stop() {
+	c.mu.Lock()
+	cancel := c.cancel
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	c.wg.Wait()
+	c.reset()
+}
+
+// reset leaves background mode.
+func (c *HealthChecker) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.cancel = nil
+	c.running = false
+	c.latest = nil
+}
+
+// loop periodically refreshes the cached result of the target at the given index.
+func (c *HealthChecker) loop(ctx context.Context, index int) {
+	target := c.targets[index]
+
+	ticker := time.NewTicker(target.checkInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result := c.runTarget(ctx, target)
+			if ctx.Err() != nil {
+				return
+			}
+			result = c.record(ctx, index, result)
+
+			c.mu.Lock()
+			c.latest[index] = result
+			c.mu.Unlock()
+		}
+	}
+}
+
+// cachedResults returns a copy of the latest background results. The second
+// return value is false when the HealthChecker is not running in background mode.
+// Results that were not refreshed for several intervals are reported as failed.
+func (c *HealthChecker) cachedResults() ([]HealthCheckResult, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.running {
+		return nil, false
+	}
+
+	results := make([]HealthCheckResult, len(c.latest))
+	copy(results, c.latest)
+
+	for i, result := range results {
+		interval := result.Target.checkInterval()
+		age := time.Since(result.CheckedAt)
+		if age > interval*staleIntervalFactor {
+			err := fmt.Errorf("health check result stale: last=%s interval=%s", age, interval)
+			results[i].Status = HealthTargetStatusFail
+			results[i].err = err
+			results[i].ErrorMessage = err.Error()
+		}
+	}
+
+	return results, true
+}
+
+// checkInterval returns the background check interval of the target.
+func (t HealthTarget) checkInterval() time.Duration {
+	if t.interval <= 0 {
+		return defaultCheckInterval
+	}
+
+	return t.interval
+}
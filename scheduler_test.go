@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_Start_ServesCachedResults(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	checker := NewHealthChecker().
+		WithTarget("counter", check.CheckFunc(func(ctx context.Context) error {
+			calls.Add(1)
+
+			return nil
+		}), WithInterval(time.Hour))
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer checker.Stop()
+
+	for range 3 {
+		results, err := checker.Check(context.Background())
+		if err != nil {
+			t.Fatalf("check: %v", err)
+		}
+		if results[0].Status != HealthTargetStatusOk {
+			t.Fatalf("expected status ok, got %s", results[0].Status)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected check to run once, ran %d times", got)
+	}
+}
+
+func TestHealthChecker_Start_RefreshesOnInterval(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	checker := NewHealthChecker().
+		WithTarget("flipping", check.CheckFunc(func(ctx context.Context) error {
+			if failing.Load() {
+				return errors.New("gone")
+			}
+
+			return nil
+		}), WithInterval(10*time.Millisecond))
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer checker.Stop()
+
+	failing.Store(true)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		results, _ := checker.Check(context.Background())
+		if results[0].Status == HealthTargetStatusFail && results[0].ErrorMessage == "gone" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("background loop did not refresh the cached result")
+}
+
+func TestHealthChecker_Start_Twice(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker()
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer checker.Stop()
+
+	if err := checker.Start(context.Background()); err == nil {
+		t.Fatal("expected error when starting twice")
+	}
+}
+
+func TestHealthChecker_Stop_ChecksInlineAgain(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	checker := NewHealthChecker().
+		WithTarget("counter", check.CheckFunc(func(ctx context.Context) error {
+			calls.Add(1)
+
+			return nil
+		}), WithInterval(time.Hour))
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	checker.Stop()
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 check runs, got %d", got)
+	}
+}
+
+func TestHealthChecker_Start_ContextDoneChecksInlineAgain(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	checker := NewHealthChecker().
+		WithTarget("counter", check.CheckFunc(func(ctx context.Context) error {
+			calls.Add(1)
+
+			return nil
+		}), WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := checker.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, running := checker.cachedResults(); !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected background mode to end when the context is done")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil || results[0].Status != HealthTargetStatusOk {
+		t.Fatalf("expected an inline check, got %+v, %v", results, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 check runs, got %d", got)
+	}
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("expected to start again, got %v", err)
+	}
+	checker.Stop()
+}
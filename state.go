@@ -0,0 +1,77 @@
+package status
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// targetState tracks the reported status of a target across check runs.
+type targetState struct {
+	mu        sync.Mutex
+	reported  HealthTargetStatus
+	failures  int
+	successes int
+	lastErr   error
+	last      HealthCheckResult
+
+	// notified is the last status sent to the status change hooks. It is
+	// guarded by the hooksMu of the HealthChecker.
+	notified HealthTargetStatus
+
+	// failuresTotal counts every failed run, regardless of flap damping.
+	failuresTotal atomic.Uint64
+}
+
+// apply updates the consecutive run counters with a fresh result and returns
+// the result to report. A target is
+// only reported as failed once its failure threshold is reached and only
+// recovers once its success threshold is reached. The first run is reported as is.
+// A failure held back by the threshold is reported without its error, which is
+// kept in the history of the target.
+func (s *targetState) apply(result HealthCheckResult) HealthCheckResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target := result.Target
+
+	if result.Status == HealthTargetStatusFail {
+		s.failuresTotal.Add(1)
+		s.failures++
+		s.successes = 0
+		s.lastErr = result.err
+
+		if s.reported == "" || s.failures >= target.failureThreshold {
+			s.reported = HealthTargetStatusFail
+		}
+	} else {
+		s.successes++
+		s.failures = 0
+
+		if s.reported != HealthTargetStatusFail || s.successes >= target.successThreshold {
+			s.reported = result.Status
+		}
+	}
+
+	switch {
+	case s.reported == HealthTargetStatusFail && result.Status != HealthTargetStatusFail && s.lastErr != nil:
+		result.err = s.lastErr
+		result.ErrorMessage = s.lastErr.Error()
+	case s.reported != HealthTargetStatusFail && result.Status == HealthTargetStatusFail:
+		result.err = nil
+		result.ErrorMessage = ""
+		result.Stack = ""
+	}
+	result.Status = s.reported
+	s.last = result
+
+	return result
+}
+
+// lastResult returns the latest reported result. The second return value is
+// false when the target has not been checked yet.
+func (s *targetState) lastResult() (HealthCheckResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.last, s.reported != ""
+}
@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+var (
+	// ErrCheckTimeout is reported when a target check does not finish within its
+	// timeout (see WithTargetTimeout) or the overall deadline (see WithCheckTimeout).
+	ErrCheckTimeout = errors.New("health check timed out")
+	// ErrCheckPanic is reported when a target check panics.
+	ErrCheckPanic = errors.New("health check panicked")
+)
+
+// WithTargetTimeout limits the duration of a single check of the target. A check
+// that does not return in time is reported as failed with ErrCheckTimeout.
+func WithTargetTimeout(timeout time.Duration) TargetOption {
+	return func(t *HealthTarget) {
+		t.timeout = timeout
+	}
+}
+
+// WithCheckTimeout sets an overall deadline for checking all targets at once.
+// Targets that did not finish by then are reported as failed with ErrCheckTimeout,
+// so that a hanging check does not delay the response until the request ends.
+func WithCheckTimeout(timeout time.Duration) CheckerOption {
+	return func(c *HealthChecker) {
+		c.checkTimeout = timeout
+	}
+}
+
+// withTimeout returns ctx limited to the given timeout. The cause of the deadline
+// is ErrCheckTimeout, so it can be told apart from the cancellation of the caller.
+func withTimeout(ctx context.Context, timeout time.Duration, what string) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w: %s of %s exceeded", ErrCheckTimeout, what, timeout))
+}
+
+// callerAborted reports whether ctx was canceled by the caller, for example a
+// disconnected client, rather than by a check deadline. Such runs say nothing
+// about the targets, so they are neither recorded in the history nor counted
+// by flap damping nor reported to hooks.
+func callerAborted(ctx context.Context) bool {
+	return ctx.Err() != nil && !errors.Is(context.Cause(ctx), ErrCheckTimeout)
+}
+
+// checkOutcome is the outcome of a single check call.
+type checkOutcome struct {
+	result check.Result
+	err    error
+	stack  string
+}
+
+// runCheck calls the check of the target in its own goroutine, recovering panics
+// into an error carrying the stack. It returns as soon as ctx is done, even if the
+// check ignores the context; the check keeps running in the background then.
+func runCheck(ctx context.Context, target HealthTarget) checkOutcome {
+	done := make(chan checkOutcome, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- checkOutcome{
+					err:   fmt.Errorf("%w: %v", ErrCheckPanic, r),
+					stack: string(debug.Stack()),
+				}
+			}
+		}()
+
+		result, err := check.Run(ctx, target.check)
+		done <- checkOutcome{result: result, err: err}
+	}()
+
+	var outcome checkOutcome
+	select {
+	case outcome = <-done:
+	case <-ctx.Done():
+		outcome = checkOutcome{err: ctx.Err()}
+	}
+
+	// Report why the context is done, such as ErrCheckTimeout, instead of the bare context error.
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(outcome.err, ctxErr) {
+		outcome.err = context.Cause(ctx)
+	}
+
+	return outcome
+}
@@ -0,0 +1,138 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alarmistdev/status/check"
+)
+
+func TestHealthChecker_Check_RecoversPanics(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("panicking", check.CheckFunc(func(ctx context.Context) error {
+			panic("boom")
+		})).
+		WithTarget("healthy", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusFail || !errors.Is(results[0].err, ErrCheckPanic) {
+		t.Fatalf("expected panic to be reported as failure, got %+v", results[0])
+	}
+	if results[0].ErrorMessage != "health check panicked: boom" {
+		t.Fatalf("unexpected error message %q", results[0].ErrorMessage)
+	}
+	if !strings.Contains(results[0].Stack, "timeout_test.go") {
+		t.Fatalf("expected stack of the panicking check, got %q", results[0].Stack)
+	}
+	if results[1].Status != HealthTargetStatusOk {
+		t.Fatalf("expected healthy target to be unaffected, got %s", results[1].Status)
+	}
+}
+
+func TestHealthChecker_WithTargetTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	checker := NewHealthChecker().
+		WithTarget("hanging", check.CheckFunc(func(ctx context.Context) error {
+			// Ignores the context on purpose.
+			<-release
+
+			return nil
+		}), WithTargetTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected check to return after the target timeout, took %s", elapsed)
+	}
+	if results[0].Status != HealthTargetStatusFail || !errors.Is(results[0].err, ErrCheckTimeout) {
+		t.Fatalf("expected timeout failure, got %+v", results[0])
+	}
+	if results[0].ErrorMessage != "health check timed out: target timeout of 20ms exceeded" {
+		t.Fatalf("unexpected error message %q", results[0].ErrorMessage)
+	}
+}
+
+func TestHealthChecker_WithCheckTimeout(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithCheckTimeout(20*time.Millisecond)).
+		WithTarget("fast", check.CheckFunc(func(ctx context.Context) error {
+			return nil
+		})).
+		WithTarget("slow", check.CheckFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}))
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusOk {
+		t.Fatalf("expected fast target to pass, got %s", results[0].Status)
+	}
+	if results[1].Status != HealthTargetStatusFail || !errors.Is(results[1].err, ErrCheckTimeout) {
+		t.Fatalf("expected slow target to time out, got %+v", results[1])
+	}
+}
+
+func TestHealthChecker_Check_CallerCancellationNotRecorded(t *testing.T) {
+	t.Parallel()
+
+	var events []StatusChangeEvent
+	checker := NewHealthChecker(WithCheckTimeout(time.Second)).
+		WithTarget("slow", check.CheckFunc(func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(200 * time.Millisecond):
+				return nil
+			}
+		})).
+		OnStatusChange(func(_ context.Context, event StatusChangeEvent) {
+			events = append(events, event)
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	results, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusFail {
+		t.Fatalf("expected the aborted run to be returned as failed, got %+v", results[0])
+	}
+
+	if len(events) != 0 {
+		t.Fatalf("expected no status events for an aborted run, got %+v", events)
+	}
+	entries, err := checker.History("slow")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no history for an aborted run, got %+v", entries)
+	}
+}
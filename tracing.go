@@ -0,0 +1,61 @@
+package status
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// tracerName is the instrumentation scope name of spans created by the HealthChecker.
+	tracerName = "github.com/alarmistdev/status"
+	// checkSpanName is the name of the span wrapping a single target check.
+	checkSpanName = "status.check"
+)
+
+// WithTracerProvider sets the OpenTelemetry tracer provider used to create a span
+// around every target check. Spans are not recorded by default.
+func WithTracerProvider(provider trace.TracerProvider) CheckerOption {
+	return func(c *HealthChecker) {
+		c.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// runTarget executes the check of a single target within a span carrying the
+// target attributes and the outcome of the check. The span context is passed
+// to the check, so that checks can propagate it to outbound requests. The check
+// waits for the concurrency limits before the span is started.
+func (c *HealthChecker) runTarget(ctx context.Context, target HealthTarget) HealthCheckResult {
+	release, err := c.acquire(ctx, target)
+	if err != nil {
+		return limitedResult(target, err)
+	}
+	defer release()
+
+	tracer := c.tracer
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer(tracerName)
+	}
+
+	ctx, span := tracer.Start(ctx, checkSpanName, trace.WithAttributes(
+		attribute.String("status.target.name", target.Name),
+		attribute.String("status.target.group", target.Group),
+		attribute.String("status.target.importance", string(target.Importance)),
+	))
+	defer span.End()
+
+	result := executeTarget(ctx, target)
+
+	span.SetAttributes(attribute.String("status.target.status", string(result.Status)))
+	if result.err != nil {
+		span.RecordError(result.err)
+	}
+	if result.Status == HealthTargetStatusFail {
+		span.SetStatus(codes.Error, result.ErrorMessage)
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alarmistdev/status/check"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHealthChecker_WithTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var spanFromCheck trace.SpanContext
+	checker := NewHealthChecker(WithTracerProvider(provider)).
+		WithTarget("postgres", check.CheckFunc(func(ctx context.Context) error {
+			spanFromCheck = trace.SpanContextFromContext(ctx)
+
+			return errors.New("connection refused")
+		}), WithGroup("Infra"))
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "status.check" {
+		t.Fatalf("unexpected span name %q", span.Name())
+	}
+	if span.SpanContext().SpanID() != spanFromCheck.SpanID() {
+		t.Fatal("expected the check to run within the span context")
+	}
+	if span.Status().Code != codes.Error {
+		t.Fatalf("expected error span status, got %v", span.Status())
+	}
+
+	attrs := make(map[attribute.Key]string)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value.AsString()
+	}
+	expected := map[attribute.Key]string{
+		"status.target.name":       "postgres",
+		"status.target.group":      "Infra",
+		"status.target.importance": "high",
+		"status.target.status":     "fail",
+	}
+	for key, want := range expected {
+		if attrs[key] != want {
+			t.Fatalf("attribute %s: expected %q, got %q", key, want, attrs[key])
+		}
+	}
+
+	if len(span.Events()) != 1 || span.Events()[0].Name != "exception" {
+		t.Fatalf("expected the error to be recorded, got events %v", span.Events())
+	}
+}